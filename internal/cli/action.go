@@ -1,8 +1,11 @@
 package cli
 
 import (
+	"errors"
 	"fmt"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/lucianoZgabriel/go-cli-todo/internal/task"
 )
@@ -21,11 +24,48 @@ func (c *CLI) addTask() error {
 		return fmt.Errorf("descrição não pode ser vazia")
 	}
 
-	task := c.todoList.AddTask(title, description)
+	priority, err := task.ParsePriority(c.readInput("🔥 Prioridade (low/medium/high/urgent, Enter para nenhuma): "))
+	if err != nil {
+		return err
+	}
+
+	due, hasTime, err := c.readDue("⏰ Prazo (AAAA-MM-DD [HH:MM], hoje, amanhã; Enter para nenhum): ")
+	if err != nil {
+		return err
+	}
+
+	var recurrence *task.Recurrence
+	if rule := c.readInput("🔁 Repetição (daily, weekly:mon,fri, monthly:15, every:3d, after:1w; Enter para nenhuma): "); rule != "" {
+		if recurrence, err = task.ParseRecurrence(rule); err != nil {
+			return err
+		}
+	}
+
+	newTask := c.todoList.AddTask(title, description)
+	if err := c.todoList.MoveTask(newTask.ID, c.targetProject()); err != nil {
+		return err
+	}
+	if err := c.todoList.SetPriority(newTask.ID, priority); err != nil {
+		return err
+	}
+	if err := c.todoList.SetDueDate(newTask.ID, due, hasTime); err != nil {
+		return err
+	}
+	if err := c.todoList.SetRecurrence(newTask.ID, recurrence); err != nil {
+		return err
+	}
+
 	fmt.Printf("\n✅ Tarefa criada com sucesso!\n")
-	fmt.Printf("🆔 ID: %d\n", task.ID)
-	fmt.Printf("📌 Título: %s\n", task.Title)
-	fmt.Printf("📄 Descrição: %s\n", task.Description)
+	fmt.Printf("🆔 ID: %d\n", newTask.ID)
+	fmt.Printf("📌 Título: %s\n", newTask.Title)
+	fmt.Printf("📄 Descrição: %s\n", newTask.Description)
+	fmt.Printf("🔥 Prioridade: %s\n", newTask.Priority.Label())
+	if newTask.HasDue() {
+		fmt.Printf("⏰ Prazo: %s\n", newTask.FormatDue())
+	}
+	if newTask.Recurrence != nil {
+		fmt.Printf("🔁 Repete: %s\n", newTask.Recurrence.Describe())
+	}
 
 	return nil
 }
@@ -34,17 +74,22 @@ func (c *CLI) addTask() error {
 func (c *CLI) listAllTasks() error {
 	fmt.Println("\n=== 📋 TODAS AS TAREFAS ===")
 
-	if len(c.todoList.Tasks) == 0 {
+	scope := c.scope()
+	if len(scope.Tasks) == 0 {
 		fmt.Println("📭 Nenhuma tarefa encontrada!")
 		return nil
 	}
 
-	fmt.Printf("📊 Total de tarefas: %d\n\n", len(c.todoList.Tasks))
-
-	for _, task := range c.todoList.Tasks {
-		c.displayTask(&task)
-		fmt.Println() // Linha em branco entre tarefas
+	spec, err := c.readSort("list", "prioridade e posição")
+	if err != nil {
+		return err
 	}
+	tasks := scope.SortedTasks()
+	spec.Sort(tasks)
+
+	fmt.Printf("📊 Total de tarefas: %d\n\n", len(scope.Tasks))
+
+	c.displayTree(tasks, true)
 
 	return nil
 }
@@ -53,19 +98,22 @@ func (c *CLI) listAllTasks() error {
 func (c *CLI) listPendingTasks() error {
 	fmt.Println("\n=== ⏳ TAREFAS PENDENTES ===")
 
-	pendingTasks := c.todoList.ListPendingTasks()
+	pendingTasks := c.scope().ListPendingTasks()
 
 	if len(pendingTasks) == 0 {
 		fmt.Println("🎉 Parabéns! Todas as tarefas foram concluídas!")
 		return nil
 	}
 
+	spec, err := c.readSort("pending", "prioridade e posição")
+	if err != nil {
+		return err
+	}
+	spec.Sort(pendingTasks)
+
 	fmt.Printf("⏳ Tarefas pendentes: %d\n\n", len(pendingTasks))
 
-	for _, task := range pendingTasks {
-		c.displayTask(&task)
-		fmt.Println()
-	}
+	c.displayTree(pendingTasks, true)
 
 	return nil
 }
@@ -82,15 +130,14 @@ func (c *CLI) toggleTaskCompleted(markAsCompleted bool) error {
 	fmt.Printf("\n=== %s MARCAR TAREFA COMO %s ===\n", emoji, strings.ToUpper(status))
 
 	// Primeiro, mostra as tarefas disponíveis
-	if len(c.todoList.Tasks) == 0 {
+	scope := c.scope()
+	if len(scope.Tasks) == 0 {
 		fmt.Println("📭 Nenhuma tarefa encontrada!")
 		return nil
 	}
 
 	fmt.Println("📋 Tarefas disponíveis:")
-	for _, task := range c.todoList.Tasks {
-		c.displayTaskSummary(&task)
-	}
+	c.displayTree(scope.SortedTasks(), false)
 	fmt.Println()
 
 	id, err := c.readInt("🆔 Digite o ID da tarefa: ")
@@ -99,27 +146,40 @@ func (c *CLI) toggleTaskCompleted(markAsCompleted bool) error {
 	}
 
 	// Verifica se a tarefa existe
-	task, err := c.todoList.GetTask(id)
+	t, err := c.todoList.GetTask(id)
 	if err != nil {
 		return err
 	}
 
 	// Verifica se a mudança é necessária
-	if task.Completed == markAsCompleted {
+	if t.Completed == markAsCompleted {
 		currentStatus := "pendente"
-		if task.Completed {
+		if t.Completed {
 			currentStatus = "concluída"
 		}
 		return fmt.Errorf("tarefa já está %s", currentStatus)
 	}
 
-	// Alterna o status
-	if err := c.todoList.ToggleTask(id); err != nil {
+	// Alterna o status; subtarefas pendentes podem ser concluídas junto
+	openBlockers := c.todoList.OpenBlockers(id)
+	err = c.todoList.ToggleTask(id)
+	if errors.Is(err, task.ErrOpenSubtasks) {
+		fmt.Printf("\n🌳 A tarefa possui %d subtarefa(s) pendente(s).\n", len(c.todoList.OpenSubtasks(id)))
+		if strings.ToLower(c.readInput("Digite 'sim' para concluir todas junto: ")) != "sim" {
+			return err
+		}
+		err = c.todoList.CompleteWithSubtasks(id)
+	}
+	if err != nil {
 		return err
 	}
 
 	fmt.Printf("\n%s Tarefa marcada como %s!\n", emoji, status)
-	fmt.Printf("📌 %s\n", task.Title)
+	fmt.Printf("📌 %s\n", t.Title)
+	if markAsCompleted {
+		warnOpenBlockers(openBlockers)
+		c.reportNextOccurrence(id)
+	}
 
 	return nil
 }
@@ -128,15 +188,14 @@ func (c *CLI) toggleTaskCompleted(markAsCompleted bool) error {
 func (c *CLI) removeTask() error {
 	fmt.Println("\n=== 🗑️ REMOVER TAREFA ===")
 
-	if len(c.todoList.Tasks) == 0 {
+	scope := c.scope()
+	if len(scope.Tasks) == 0 {
 		fmt.Println("📭 Nenhuma tarefa encontrada!")
 		return nil
 	}
 
 	fmt.Println("📋 Tarefas disponíveis:")
-	for _, task := range c.todoList.Tasks {
-		c.displayTaskSummary(&task)
-	}
+	c.displayTree(scope.SortedTasks(), false)
 	fmt.Println()
 
 	id, err := c.readInt("🆔 Digite o ID da tarefa para remover: ")
@@ -161,29 +220,182 @@ func (c *CLI) removeTask() error {
 		return nil
 	}
 
+	// Subtarefas só são removidas com confirmação explícita
+	if children := c.todoList.Children(id); len(children) > 0 {
+		fmt.Printf("\n🌳 A tarefa possui %d subtarefa(s) que também serão removidas.\n", len(children))
+		if strings.ToLower(c.readInput("Digite 'sim' para remover tudo: ")) != "sim" {
+			fmt.Println("❌ Remoção cancelada.")
+			return nil
+		}
+		if err := c.todoList.RemoveTaskTree(id); err != nil {
+			return err
+		}
+		fmt.Printf("🗑️ Tarefa e subtarefas movidas para a lixeira!\n")
+		return nil
+	}
+
 	if err := c.todoList.RemoveTask(id); err != nil {
 		return err
 	}
 
-	fmt.Printf("🗑️ Tarefa removida com sucesso!\n")
+	fmt.Printf("🗑️ Tarefa movida para a lixeira!\n")
+	return nil
+}
+
+// editTask edita uma tarefa mostrando os valores atuais. Enter mantém o
+// valor do campo e "-" limpa os campos opcionais.
+func (c *CLI) editTask() error {
+	fmt.Println("\n=== ✏️  EDITAR TAREFA ===")
+
+	scope := c.scope()
+	if len(scope.Tasks) == 0 {
+		fmt.Println("📭 Nenhuma tarefa encontrada!")
+		return nil
+	}
+
+	fmt.Println("📋 Tarefas disponíveis:")
+	c.displayTree(scope.SortedTasks(), false)
+	fmt.Println()
+
+	id, err := c.readInt("🆔 Digite o ID da tarefa para editar: ")
+	if err != nil {
+		return fmt.Errorf("ID inválido: %w", err)
+	}
+
+	t, err := c.todoList.GetTask(id)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("\nEnter mantém o valor atual; '-' limpa os campos opcionais.")
+	var update task.TaskUpdate
+	update.Title = c.readField("📌 Título", t.Title, false)
+	update.Description = c.readField("📄 Descrição", t.Description, true)
+	if input := c.readField("🔥 Prioridade", t.Priority.String(), true); input != nil {
+		priority, err := task.ParsePriority(*input)
+		if err != nil {
+			return err
+		}
+		update.Priority = &priority
+	}
+	update.Due = c.readField("⏰ Prazo", t.FormatDue(), true)
+	update.Recurrence = c.readField("🔁 Repetição", recurrenceRule(t.Recurrence), true)
+	if input := c.readField("🏷️  Tags", strings.Join(t.Tags, ", "), true); input != nil {
+		var tags []string
+		if *input != "" {
+			if tags, err = parseTagList(*input); err != nil {
+				return err
+			}
+		}
+		update.Tags = &tags
+	}
+	if !t.IsSubtask() {
+		update.Project = c.readField("📁 Projeto", c.todoList.ProjectName(t.ProjectID), false)
+	}
+
+	if update.IsEmpty() {
+		fmt.Println("ℹ️  Nenhum campo alterado.")
+		return nil
+	}
+
+	changes, err := c.todoList.UpdateTask(id, update)
+	if err != nil {
+		return err
+	}
+	reportChanges(id, changes)
 	return nil
 }
 
+// editTaskInEditor escolhe uma tarefa e a abre no editor externo, para
+// textos longos como descrições com várias linhas
+func (c *CLI) editTaskInEditor() error {
+	fmt.Println("\n=== 📝 EDITAR NO EDITOR ===")
+
+	scope := c.scope()
+	if len(scope.Tasks) == 0 {
+		fmt.Println("📭 Nenhuma tarefa encontrada!")
+		return nil
+	}
+
+	fmt.Println("📋 Tarefas disponíveis:")
+	c.displayTree(scope.SortedTasks(), false)
+	fmt.Println()
+
+	id, err := c.readInt("🆔 Digite o ID da tarefa para editar: ")
+	if err != nil {
+		return fmt.Errorf("ID inválido: %w", err)
+	}
+	return c.editInEditor(id)
+}
+
+// readField lê o novo valor de um campo exibindo o atual. Retorna nil se o
+// usuário manteve o valor (Enter) e, em campos opcionais, "" para "-".
+func (c *CLI) readField(label, current string, optional bool) *string {
+	shown := current
+	if shown == "" {
+		shown = "vazio"
+	}
+
+	input := c.readInput(fmt.Sprintf("%s [%s]: ", label, shown))
+	switch {
+	case input == "" || input == current:
+		return nil
+	case input == "-" && optional:
+		input = ""
+		if current == "" {
+			return nil
+		}
+	}
+	return &input
+}
+
+// recurrenceRule retorna a regra de repetição no formato aceito na entrada
+func recurrenceRule(r *task.Recurrence) string {
+	if r == nil {
+		return ""
+	}
+	return r.String()
+}
+
+// reportChanges informa os campos alterados de uma tarefa
+func reportChanges(id int, changes []task.FieldChange) {
+	if len(changes) == 0 {
+		fmt.Printf("ℹ️  Tarefa [%d] sem alterações\n", id)
+		return
+	}
+
+	fmt.Printf("✏️ Tarefa [%d] editada com sucesso!\n", id)
+	for _, change := range changes {
+		fmt.Printf("   %s\n", describeChange(change))
+	}
+}
+
 // searchTasks busca tarefas por termo
 func (c *CLI) searchTasks() error {
 	fmt.Println("\n=== 🔍 BUSCAR TAREFAS ===")
 
-	if len(c.todoList.Tasks) == 0 {
+	scope := c.scope()
+	if len(scope.Tasks) == 0 {
 		fmt.Println("📭 Nenhuma tarefa encontrada!")
 		return nil
 	}
 
-	query := c.readInput("🔍 Digite o termo de busca: ")
+	fmt.Println("💡 Ex.: deploy tag:infra status:pending due<2026-11-01 -wip (OR, NOT e parênteses também valem)")
+	query := c.readInput("🔍 Digite a busca: ")
 	if query == "" {
 		return fmt.Errorf("termo de busca não pode ser vazio")
 	}
 
-	results := c.todoList.SearchTasks(query)
+	parsed, err := parseQuery(query)
+	if err != nil {
+		return err
+	}
+	spec, err := c.readSort("search", "relevância")
+	if err != nil {
+		return err
+	}
+	results := parsed.Rank(c.todoList, scope.Tasks, time.Now())
+	spec.SortResults(results)
 
 	if len(results) == 0 {
 		fmt.Printf("❌ Nenhuma tarefa encontrada para '%s'\n", query)
@@ -192,7 +404,8 @@ func (c *CLI) searchTasks() error {
 
 	fmt.Printf("✅ Encontradas %d tarefa(s) para '%s':\n\n", len(results), query)
 
-	for _, task := range results {
+	for _, result := range results {
+		task := c.highlighted(result)
 		c.displayTask(&task)
 		fmt.Println()
 	}
@@ -202,23 +415,530 @@ func (c *CLI) searchTasks() error {
 
 // displayTask exibe uma tarefa completa
 func (c *CLI) displayTask(t *task.Task) {
+	c.displayTaskAt(t, "")
+}
+
+// displayTaskAt exibe uma tarefa completa com cada linha precedida por indent
+func (c *CLI) displayTaskAt(t *task.Task, indent string) {
 	status := "⏳ Pendente"
 	if t.Completed {
 		status = "✅ Concluída"
 	}
 
-	fmt.Printf("🆔 ID: %d\n", t.ID)
-	fmt.Printf("📌 Título: %s\n", t.Title)
-	fmt.Printf("📄 Descrição: %s\n", t.Description)
-	fmt.Printf("📊 Status: %s\n", status)
-	fmt.Printf("📅 Criada em: %s\n", t.CreatedAt.Format("02/01/2006 15:04"))
+	fmt.Printf("%s🆔 ID: %d\n", indent, t.ID)
+	fmt.Printf("%s📌 Título: %s\n", indent, t.Title)
+	fmt.Printf("%s📄 Descrição: %s\n", indent, t.Description)
+	fmt.Printf("%s📊 Status: %s\n", indent, status)
+	fmt.Printf("%s🔥 Prioridade: %s\n", indent, t.Priority.Label())
+	if len(t.Tags) > 0 {
+		fmt.Printf("%s🏷️  Tags: %s\n", indent, formatTags(t.Tags))
+	}
+	if t.HasDue() {
+		overdue := ""
+		if t.IsOverdue(time.Now()) {
+			overdue = " ⚠️  ATRASADA"
+		}
+		fmt.Printf("%s⏰ Prazo: %s%s\n", indent, t.FormatDue(), overdue)
+	}
+	if t.Recurrence != nil {
+		fmt.Printf("%s🔁 Repete: %s\n", indent, t.Recurrence.Describe())
+	}
+	if series := c.todoList.Series(t.ID); len(series) > 1 {
+		fmt.Printf("%s📚 Série: %d ocorrência(s)\n", indent, len(series))
+	}
+	if t.IsSubtask() {
+		fmt.Printf("%s⬆️  Subtarefa de: [%d]\n", indent, t.ParentID)
+	}
+	if blockers := c.todoList.Blockers(t.ID); len(blockers) > 0 {
+		fmt.Printf("%s⛔ Depende de: %s\n", indent, formatTaskRefs(blockers))
+	}
+	if done, total := c.todoList.Progress(t.ID); total > 0 {
+		fmt.Printf("%s🌳 Subtarefas: %d/%d concluídas\n", indent, done, total)
+	}
+	fmt.Printf("%s📅 Criada em: %s\n", indent, t.CreatedAt.Format("02/01/2006 15:04"))
+	if t.UpdatedAt != nil {
+		fmt.Printf("%s🕒 Atualizada em: %s\n", indent, t.UpdatedAt.Format("02/01/2006 15:04"))
+	}
+	if t.CompletedAt != nil {
+		fmt.Printf("%s🏁 Concluída em: %s\n", indent, t.CompletedAt.Format("02/01/2006 15:04"))
+	}
 }
 
 // displayTaskSummary exibe um resumo da tarefa
 func (c *CLI) displayTaskSummary(t *task.Task) {
+	c.displayTaskSummaryAt(t, "")
+}
+
+// displayTaskSummaryAt exibe um resumo da tarefa precedido por indent
+func (c *CLI) displayTaskSummaryAt(t *task.Task, indent string) {
 	status := "⏳"
 	if t.Completed {
 		status = "✅"
 	}
-	fmt.Printf("  %s [%d] %s\n", status, t.ID, t.Title)
+	details := ""
+	if t.Priority != task.PriorityNone {
+		details += " " + t.Priority.Label()
+	}
+	if len(t.Tags) > 0 {
+		details += " " + formatTags(t.Tags)
+	}
+	if t.Recurrence != nil {
+		details += " 🔁"
+	}
+	if t.HasDue() {
+		details += " ⏰ " + t.FormatDue()
+		if t.IsOverdue(time.Now()) {
+			details += " ⚠️  atrasada"
+		}
+	}
+	if done, total := c.todoList.Progress(t.ID); total > 0 {
+		details += fmt.Sprintf(" 🌳 %d/%d", done, total)
+	}
+	if !t.Completed {
+		if open := c.todoList.OpenBlockers(t.ID); len(open) > 0 {
+			details += " ⛔ aguardando " + formatTaskIDs(open)
+		}
+	}
+	fmt.Printf("  %s%s [%d] %s%s\n", indent, status, t.ID, t.Title, details)
+}
+
+// displayTree exibe as tarefas em árvore, com as subtarefas indentadas
+// abaixo da tarefa principal. Com detailed, cada tarefa é exibida completa.
+func (c *CLI) displayTree(tasks []task.Task, detailed bool) {
+	for _, item := range task.BuildTree(tasks) {
+		branch, indent := "", ""
+		if item.Depth > 0 {
+			indent = strings.Repeat("   ", item.Depth)
+			branch = strings.Repeat("   ", item.Depth-1) + "└─ "
+		}
+
+		if !detailed {
+			c.displayTaskSummaryAt(&item.Task, branch)
+			continue
+		}
+
+		c.displayTaskAt(&item.Task, indent)
+		fmt.Println() // Linha em branco entre tarefas
+	}
+}
+
+// addSubtask adiciona uma subtarefa a uma tarefa existente
+func (c *CLI) addSubtask() error {
+	fmt.Println("\n=== 🌳 ADICIONAR SUBTAREFA ===")
+
+	scope := c.scope()
+	if len(scope.Tasks) == 0 {
+		fmt.Println("📭 Nenhuma tarefa encontrada!")
+		return nil
+	}
+
+	fmt.Println("📋 Tarefas disponíveis:")
+	c.displayTree(scope.SortedTasks(), false)
+	fmt.Println()
+
+	parentID, err := c.readInt("🆔 Digite o ID da tarefa principal: ")
+	if err != nil {
+		return fmt.Errorf("ID inválido: %w", err)
+	}
+	if _, err := c.todoList.GetTask(parentID); err != nil {
+		return err
+	}
+
+	title := c.readInput("📌 Título da subtarefa: ")
+	if title == "" {
+		return fmt.Errorf("título não pode ser vazio")
+	}
+	description := c.readInput("📄 Descrição da subtarefa: ")
+
+	subtask, err := c.todoList.AddSubtask(parentID, title, description)
+	if err != nil {
+		return err
+	}
+
+	done, total := c.todoList.Progress(parentID)
+	fmt.Printf("\n✅ Subtarefa [%d] criada em [%d]!\n", subtask.ID, parentID)
+	fmt.Printf("🌳 Subtarefas: %d/%d concluídas\n", done, total)
+	return nil
+}
+
+// manualOrder lista as tarefas na ordem manual
+var manualOrder = task.SortSpec{{Key: task.SortRank}, {Key: task.SortID}}
+
+// moveTask muda a posição de uma tarefa na ordem manual
+func (c *CLI) moveTask() error {
+	fmt.Println("\n=== ↕️  REORDENAR TAREFA ===")
+
+	tasks := c.scope().SortedTasks()
+	if len(tasks) == 0 {
+		fmt.Println("📭 Nenhuma tarefa encontrada!")
+		return nil
+	}
+
+	fmt.Println("📋 Tarefas na ordem manual:")
+	manualOrder.Sort(tasks)
+	c.displayTree(tasks, false)
+	fmt.Println()
+
+	id, err := c.readInt("🆔 Digite o ID da tarefa a mover: ")
+	if err != nil {
+		return fmt.Errorf("ID inválido: %w", err)
+	}
+
+	input := c.readInput("↕️  Mover para: 't' (topo), 'f' (fim) ou o ID da tarefa que ficará logo depois: ")
+	switch strings.ToLower(input) {
+	case "t":
+		err = c.todoList.MoveTaskToTop(id)
+	case "f":
+		err = c.todoList.MoveTaskToBottom(id)
+	default:
+		beforeID, convErr := strconv.Atoi(input)
+		if convErr != nil {
+			return fmt.Errorf("posição inválida: %s", input)
+		}
+		err = c.todoList.MoveTaskBefore(id, beforeID)
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("↕️  Tarefa [%d] reordenada!\n", id)
+	return nil
+}
+
+// manageDependencies adiciona ou remove dependências de uma tarefa
+func (c *CLI) manageDependencies() error {
+	fmt.Println("\n=== ⛓️ GERENCIAR DEPENDÊNCIAS ===")
+
+	scope := c.scope()
+	if len(scope.Tasks) == 0 {
+		fmt.Println("📭 Nenhuma tarefa encontrada!")
+		return nil
+	}
+
+	fmt.Println("📋 Tarefas disponíveis:")
+	c.displayTree(scope.SortedTasks(), false)
+	fmt.Println()
+
+	id, err := c.readInt("🆔 Digite o ID da tarefa bloqueada: ")
+	if err != nil {
+		return fmt.Errorf("ID inválido: %w", err)
+	}
+	if _, err := c.todoList.GetTask(id); err != nil {
+		return err
+	}
+
+	fmt.Println("ℹ️  Use +ID para adicionar e -ID para remover dependências (ex.: +7 +9 -3)")
+	input := c.readInput("⛓️  Dependências: ")
+	if input == "" {
+		return fmt.Errorf("nenhuma dependência informada")
+	}
+
+	for _, token := range strings.Fields(input) {
+		remove := strings.HasPrefix(token, "-")
+		blockerID, err := strconv.Atoi(strings.TrimLeft(token, "+-"))
+		if err != nil {
+			return fmt.Errorf("ID inválido: %s", token)
+		}
+
+		if remove {
+			err = c.todoList.RemoveDependency(id, blockerID)
+		} else {
+			err = c.todoList.AddDependency(id, blockerID)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	fmt.Printf("\n⛓️  Dependências atualizadas!\n")
+	if blockers := c.todoList.Blockers(id); len(blockers) > 0 {
+		fmt.Printf("⛔ Depende de: %s\n", formatTaskRefs(blockers))
+	} else {
+		fmt.Println("✅ A tarefa não depende de nenhuma outra.")
+	}
+	return nil
+}
+
+// listReadyTasks lista as tarefas pendentes sem dependências em aberto
+func (c *CLI) listReadyTasks() error {
+	fmt.Println("\n=== 🚀 TAREFAS PRONTAS PARA COMEÇAR ===")
+
+	ready := c.inScope(c.todoList.ReadyTasks())
+	if len(ready) == 0 {
+		fmt.Println("📭 Nenhuma tarefa pronta no momento!")
+		return nil
+	}
+
+	spec, err := c.readSort("ready", "prioridade e posição")
+	if err != nil {
+		return err
+	}
+	spec.Sort(ready)
+
+	fmt.Printf("🚀 Tarefas prontas: %d\n\n", len(ready))
+	for _, t := range ready {
+		c.displayTaskSummary(&t)
+	}
+	return nil
+}
+
+// manageTags adiciona ou remove tags de uma tarefa
+func (c *CLI) manageTags() error {
+	fmt.Println("\n=== 🏷️ GERENCIAR TAGS ===")
+
+	scope := c.scope()
+	if len(scope.Tasks) == 0 {
+		fmt.Println("📭 Nenhuma tarefa encontrada!")
+		return nil
+	}
+
+	fmt.Println("📋 Tarefas disponíveis:")
+	c.displayTree(scope.SortedTasks(), false)
+	fmt.Println()
+
+	id, err := c.readInt("🆔 Digite o ID da tarefa: ")
+	if err != nil {
+		return fmt.Errorf("ID inválido: %w", err)
+	}
+
+	if _, err := c.todoList.GetTask(id); err != nil {
+		return err
+	}
+
+	fmt.Println("ℹ️  Use +tag para adicionar e -tag para remover (ex.: +infra -wip)")
+	input := c.readInput("🏷️  Tags: ")
+	if input == "" {
+		return fmt.Errorf("nenhuma tag informada")
+	}
+
+	var toAdd, toRemove []string
+	for _, token := range strings.Fields(input) {
+		if strings.HasPrefix(token, "-") {
+			toRemove = append(toRemove, strings.TrimPrefix(token, "-"))
+			continue
+		}
+		toAdd = append(toAdd, strings.TrimPrefix(token, "+"))
+	}
+
+	if err := c.todoList.AddTags(id, toAdd...); err != nil {
+		return err
+	}
+	if err := c.todoList.RemoveTags(id, toRemove...); err != nil {
+		return err
+	}
+
+	t, _ := c.todoList.GetTask(id)
+	fmt.Printf("\n🏷️  Tags atualizadas!\n")
+	fmt.Printf("📌 %s %s\n", t.Title, formatTags(t.Tags))
+	return nil
+}
+
+// listTasksByTag lista as tarefas que possuem as tags informadas
+func (c *CLI) listTasksByTag() error {
+	fmt.Println("\n=== 🔖 FILTRAR POR TAG ===")
+
+	scope := c.scope()
+	tagCounts := scope.TagCounts()
+	if len(tagCounts) == 0 {
+		fmt.Println("📭 Nenhuma tag cadastrada!")
+		return nil
+	}
+
+	fmt.Println("🏷️  Tags disponíveis:")
+	for _, tc := range tagCounts {
+		fmt.Printf("  #%s (%d)\n", tc.Tag, tc.Count)
+	}
+	fmt.Println()
+
+	tags, err := parseTagList(c.readInput("🔖 Tags (separadas por espaço ou vírgula): "))
+	if err != nil {
+		return err
+	}
+
+	results := task.FilterByTags(scope.SortedTasks(), tags)
+	if len(results) == 0 {
+		fmt.Printf("❌ Nenhuma tarefa encontrada com %s\n", formatTags(tags))
+		return nil
+	}
+
+	fmt.Printf("✅ %d tarefa(s) com %s:\n\n", len(results), formatTags(tags))
+	for _, t := range results {
+		c.displayTask(&t)
+		fmt.Println()
+	}
+
+	return nil
+}
+
+// showStatistics exibe as estatísticas da lista
+func (c *CLI) showStatistics() error {
+	fmt.Println()
+	c.displayStatistics()
+	return nil
+}
+
+// showAgenda exibe a agenda dos próximos dias
+func (c *CLI) showAgenda() error {
+	fmt.Println("\n=== 📅 AGENDA ===")
+	c.displayAgenda(defaultAgendaDays)
+	return nil
+}
+
+// displayAgenda exibe as tarefas atrasadas e as que vencem nos próximos
+// days dias, agrupadas por dia
+func (c *CLI) displayAgenda(days int) {
+	now := time.Now()
+	overdue, agenda := c.scope().Agenda(now, days)
+
+	if len(overdue) == 0 && len(agenda) == 0 {
+		fmt.Printf("📭 Nenhuma tarefa com prazo nos próximos %d dia(s)!\n", days)
+		return
+	}
+
+	if len(overdue) > 0 {
+		fmt.Printf("⚠️  Atrasadas (%d):\n", len(overdue))
+		for _, t := range overdue {
+			c.displayTaskSummary(&t)
+		}
+		fmt.Println()
+	}
+
+	for _, day := range agenda {
+		fmt.Printf("📆 %s (%d):\n", formatAgendaDay(day.Date, now), len(day.Tasks))
+		for _, t := range day.Tasks {
+			c.displayTaskSummary(&t)
+		}
+		fmt.Println()
+	}
+}
+
+// formatAgendaDay formata o cabeçalho de um dia da agenda
+func formatAgendaDay(day, now time.Time) string {
+	date := fmt.Sprintf("%s %s", weekdayNames[day.Weekday()], day.Format("02/01"))
+
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	switch {
+	case day.Equal(today):
+		return "Hoje, " + date
+	case day.Equal(today.AddDate(0, 0, 1)):
+		return "Amanhã, " + date
+	default:
+		return date
+	}
+}
+
+// weekdayNames traduz os dias da semana para exibição
+var weekdayNames = map[time.Weekday]string{
+	time.Sunday:    "Domingo",
+	time.Monday:    "Segunda",
+	time.Tuesday:   "Terça",
+	time.Wednesday: "Quarta",
+	time.Thursday:  "Quinta",
+	time.Friday:    "Sexta",
+	time.Saturday:  "Sábado",
+}
+
+// displayStatistics exibe as estatísticas da lista
+func (c *CLI) displayStatistics() {
+	scope := c.scope()
+	total, completed, pending := scope.Stats()
+
+	fmt.Println("📊 === ESTATÍSTICAS DAS TAREFAS ===")
+	fmt.Printf("📝 Total de tarefas:     %d\n", total)
+	if total == 0 {
+		return
+	}
+
+	fmt.Printf("✅ Tarefas concluídas:   %d (%.1f%%)\n", completed, percent(completed, total))
+	fmt.Printf("⏳ Tarefas pendentes:    %d (%.1f%%)\n", pending, percent(pending, total))
+
+	now := time.Now()
+	fmt.Printf("⚠️  Atrasadas:            %d\n", len(scope.OverdueTasks(now)))
+	fmt.Printf("📆 Vencem hoje:          %d\n", len(scope.DueTodayTasks(now)))
+	fmt.Printf("🗓️  Vencem nesta semana:  %d\n", len(scope.DueThisWeekTasks(now)))
+
+	tagCounts := scope.TagCounts()
+	if len(tagCounts) > 0 {
+		cloud := make([]string, 0, len(tagCounts))
+		for _, tc := range tagCounts {
+			cloud = append(cloud, fmt.Sprintf("#%s (%d)", tc.Tag, tc.Count))
+		}
+		fmt.Printf("🏷️  Tags: %s\n", strings.Join(cloud, "  "))
+	}
+
+	if progress := scope.ParentsProgress(); len(progress) > 0 {
+		fmt.Println("🌳 Progresso das subtarefas:")
+		for _, p := range progress {
+			fmt.Printf("  [%d] %s — %d/%d subtarefas concluídas (%.0f%%)\n",
+				p.Task.ID, p.Task.Title, p.Done, p.Total, percent(p.Done, p.Total))
+		}
+	}
+
+	if c.project == allProjects {
+		fmt.Println("📁 Projetos:")
+		for _, ps := range c.todoList.ProjectStats() {
+			fmt.Printf("  %s\n", projectStatsLine(ps))
+		}
+	}
+}
+
+// reportNextOccurrence informa a ocorrência criada ao concluir uma tarefa recorrente
+func (c *CLI) reportNextOccurrence(id int) {
+	done, err := c.todoList.GetTask(id)
+	if err != nil || done.NextOccurrenceID == 0 {
+		return
+	}
+
+	next, err := c.todoList.GetTask(done.NextOccurrenceID)
+	if err != nil {
+		return
+	}
+	fmt.Printf("🔁 Próxima ocorrência: [%d] %s ⏰ %s\n", next.ID, next.Title, next.FormatDue())
+}
+
+// warnOpenBlockers avisa que uma tarefa foi concluída antes das suas dependências
+func warnOpenBlockers(blockers []task.Task) {
+	if len(blockers) == 0 {
+		return
+	}
+	fmt.Printf("⚠️  Atenção: a tarefa foi concluída, mas ainda depende de %s\n", formatTaskRefs(blockers))
+}
+
+// formatTaskRefs formata tarefas no estilo "[7] Título ⏳, [9] Título ✅"
+func formatTaskRefs(tasks []task.Task) string {
+	refs := make([]string, len(tasks))
+	for i, t := range tasks {
+		status := "⏳"
+		if t.Completed {
+			status = "✅"
+		}
+		refs[i] = fmt.Sprintf("[%d] %s %s", t.ID, t.Title, status)
+	}
+	return strings.Join(refs, ", ")
+}
+
+// formatTaskIDs formata os IDs das tarefas no estilo "[7, 9]"
+func formatTaskIDs(tasks []task.Task) string {
+	ids := make([]string, len(tasks))
+	for i, t := range tasks {
+		ids[i] = strconv.Itoa(t.ID)
+	}
+	return "[" + strings.Join(ids, ", ") + "]"
+}
+
+// formatTags formata as tags no estilo "#tag1 #tag2"
+func formatTags(tags []string) string {
+	formatted := make([]string, len(tags))
+	for i, tag := range tags {
+		formatted[i] = "#" + tag
+	}
+	return strings.Join(formatted, " ")
+}
+
+// percent calcula a porcentagem de part em relação a total
+func percent(part, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(part) / float64(total) * 100
 }
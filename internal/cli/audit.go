@@ -0,0 +1,55 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/lucianoZgabriel/go-cli-todo/internal/task"
+)
+
+// eventLabels descreve cada tipo de evento do histórico de uma tarefa
+var eventLabels = map[task.EventKind]string{
+	task.EventCreated:   "➕ Criada",
+	task.EventEdited:    "✏️  Editada",
+	task.EventCompleted: "✅ Concluída",
+	task.EventReopened:  "🔄 Reaberta",
+	task.EventDeleted:   "🗑️  Movida para a lixeira",
+	task.EventRestored:  "♻️  Restaurada da lixeira",
+}
+
+// displayTimeline exibe o histórico de auditoria de uma tarefa
+func (c *CLI) displayTimeline(t *task.Task) {
+	fmt.Printf("📜 Histórico da tarefa [%d] %s\n", t.ID, t.Title)
+	for _, event := range t.Timeline() {
+		label, ok := eventLabels[event.Kind]
+		if !ok {
+			label = string(event.Kind)
+		}
+		fmt.Printf("  %s  %s\n", event.At.Format("02/01/2006 15:04"), label)
+		for _, change := range event.Changes {
+			fmt.Printf("                      %s\n", describeChange(change))
+		}
+	}
+}
+
+// describeChange formata a alteração de um campo, no mesmo estilo usado
+// ao editar uma tarefa
+func describeChange(change task.FieldChange) string {
+	label := task.FieldLabel(change.Field)
+	if change.Field == task.FieldDescription {
+		switch {
+		case change.From == "":
+			return fmt.Sprintf("Descrição adicionada: '%s'", change.To)
+		case change.To == "":
+			return fmt.Sprintf("Descrição removida: '%s'", change.From)
+		}
+	}
+	return fmt.Sprintf("%s: %s → %s", label, quoteValue(change.From), quoteValue(change.To))
+}
+
+// quoteValue destaca o valor de um campo, indicando quando ele está vazio
+func quoteValue(value string) string {
+	if value == "" {
+		return "(vazio)"
+	}
+	return "'" + value + "'"
+}
@@ -0,0 +1,129 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+// autosave grava as alterações feitas pela última ação do menu. Com
+// debounce configurado, a gravação é adiada até que passe o intervalo sem
+// novas alterações; o salvamento pendente é feito enquanto o menu espera
+// pela próxima entrada (veja readInput) ou ao encerrar.
+func (c *CLI) autosave() {
+	if !c.dirty() {
+		return
+	}
+	if !c.debounce.schedule() {
+		c.flush(c.interactive)
+	}
+}
+
+// debouncer adia uma ação até que passe delay sem novos pedidos: cada
+// pedido descarta o prazo anterior e começa a contar de novo
+type debouncer struct {
+	delay time.Duration
+	after func(time.Duration) <-chan time.Time // time.After; trocado nos testes
+	due   <-chan time.Time
+}
+
+// newDebouncer cria um debouncer; delay zero faz a ação na hora
+func newDebouncer(delay time.Duration) *debouncer {
+	return &debouncer{delay: delay, after: time.After}
+}
+
+// schedule adia a ação por delay a partir de agora. Retorna false se não
+// há debounce e a ação deve ser feita imediatamente.
+func (d *debouncer) schedule() bool {
+	if d.delay <= 0 {
+		return false
+	}
+	d.due = d.after(d.delay)
+	return true
+}
+
+// C retorna o canal que dispara quando a ação pendente vence, ou nil (que
+// nunca é escolhido em um select) se não houver ação pendente
+func (d *debouncer) C() <-chan time.Time {
+	return d.due
+}
+
+// cancel descarta a ação pendente, já atendida ou feita por outro caminho
+func (d *debouncer) cancel() {
+	d.due = nil
+}
+
+// flush grava os dados e informa erros sem interromper o menu. Sem
+// interação, conflitos com outro processo são mesclados automaticamente.
+func (c *CLI) flush(interactive bool) {
+	previous := c.interactive
+	c.interactive = interactive
+	defer func() { c.interactive = previous }()
+
+	if err := c.persist(); err != nil {
+		fmt.Printf("❌ Erro ao salvar automaticamente: %s\n", err)
+	}
+}
+
+// persist salva os dados e registra o conteúdo salvo, cancelando qualquer
+// salvamento pendente
+func (c *CLI) persist() error {
+	if err := c.saveData(); err != nil {
+		return err
+	}
+
+	c.debounce.cancel()
+	c.saved = c.snapshot()
+	return nil
+}
+
+// dirty informa se a lista mudou desde o último Load/Save
+func (c *CLI) dirty() bool {
+	return !bytes.Equal(c.snapshot(), c.saved)
+}
+
+// snapshot serializa a lista para comparar versões
+func (c *CLI) snapshot() []byte {
+	data, err := json.Marshal(c.todoList)
+	if err != nil {
+		return nil
+	}
+	return data
+}
+
+// handleSignals passa a receber Ctrl-C (SIGINT) e SIGTERM, atendidos por
+// readInput para salvar antes de encerrar
+func (c *CLI) handleSignals() {
+	c.signals = make(chan os.Signal, 1)
+	signal.Notify(c.signals, os.Interrupt, syscall.SIGTERM)
+}
+
+// shutdown salva as alterações pendentes e encerra o programa
+func (c *CLI) shutdown(reason string, code int) {
+	fmt.Printf("⏹️  %s, encerrando...\n", reason)
+
+	c.interactive = false
+	if c.dirty() {
+		if err := c.persist(); err != nil {
+			fmt.Fprintf(os.Stderr, "❌ Erro ao salvar: %v\n", err)
+			c.exit(ExitError)
+			return
+		}
+		fmt.Println("💾 Dados salvos com sucesso!")
+	}
+
+	fmt.Println("👋 Até mais!")
+	c.exit(code)
+}
+
+// signalExitCode segue a convenção dos shells: 128 + número do sinal
+func signalExitCode(sig os.Signal) int {
+	if s, ok := sig.(syscall.Signal); ok {
+		return 128 + int(s)
+	}
+	return ExitError
+}
@@ -0,0 +1,233 @@
+package cli
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"os"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/lucianoZgabriel/go-cli-todo/internal/task"
+)
+
+// memStorage guarda a lista em memória e avisa em saved a cada gravação
+type memStorage struct {
+	list  *task.TodoList
+	saved chan int // quantidade de tarefas gravadas
+	err   error
+}
+
+func newMemStorage() *memStorage {
+	return &memStorage{list: task.NewTodoList(), saved: make(chan int, 10)}
+}
+
+func (s *memStorage) Load() (*task.TodoList, error) {
+	return s.list, nil
+}
+
+func (s *memStorage) Save(tl *task.TodoList) error {
+	if s.err != nil {
+		return s.err
+	}
+	s.saved <- len(tl.Tasks)
+	return nil
+}
+
+// fakeAfter troca time.After do debouncer por canais disparados pelo teste
+type fakeAfter struct {
+	delays []time.Duration
+	timers []chan time.Time
+}
+
+func (f *fakeAfter) after(d time.Duration) <-chan time.Time {
+	timer := make(chan time.Time, 1)
+	f.delays = append(f.delays, d)
+	f.timers = append(f.timers, timer)
+	return timer
+}
+
+// fire dispara o último prazo agendado
+func (f *fakeAfter) fire() {
+	f.timers[len(f.timers)-1] <- time.Now()
+}
+
+// exitCall é o pânico usado no lugar de os.Exit nos testes
+type exitCall int
+
+// exited executa fn e retorna o código com que ela encerrou o programa
+func exited(t *testing.T, c *CLI, fn func()) (code int) {
+	t.Helper()
+	c.exit = func(code int) { panic(exitCall(code)) }
+	defer func() {
+		call, ok := recover().(exitCall)
+		if !ok {
+			t.Fatal("o programa não foi encerrado")
+		}
+		code = int(call)
+	}()
+	fn()
+	return -1
+}
+
+// memCLI cria uma CLI com a lista carregada de um memStorage
+func memCLI(t *testing.T, opts ...Option) (*CLI, *memStorage) {
+	t.Helper()
+	store := newMemStorage()
+	c := NewCLI(store, opts...)
+	if err := c.loadData(); err != nil {
+		t.Fatal(err)
+	}
+	return c, store
+}
+
+func TestDebouncer(t *testing.T) {
+	if d := newDebouncer(0); d.schedule() || d.C() != nil {
+		t.Error("sem debounce, a ação deveria ser feita na hora")
+	}
+
+	fake := &fakeAfter{}
+	d := &debouncer{delay: 2 * time.Second, after: fake.after}
+	if d.C() != nil {
+		t.Error("ação pendente antes de qualquer pedido")
+	}
+	for range 3 {
+		if !d.schedule() {
+			t.Fatal("schedule com debounce retornou false")
+		}
+	}
+	// Só o último prazo vale: os anteriores foram descartados
+	if len(fake.delays) != 3 || fake.delays[2] != 2*time.Second {
+		t.Errorf("prazos agendados: %v", fake.delays)
+	}
+	if d.C() != (<-chan time.Time)(fake.timers[2]) {
+		t.Error("C não é o canal do último prazo")
+	}
+	d.cancel()
+	if d.C() != nil {
+		t.Error("ação pendente após cancel")
+	}
+}
+
+// Com debounce, as alterações só são gravadas quando o prazo vence, o que
+// acontece enquanto o menu espera pela próxima linha
+func TestAutosaveDebounce(t *testing.T) {
+	c, store := memCLI(t, WithAutosaveDelay(time.Second))
+	fake := &fakeAfter{}
+	c.debounce.after = fake.after
+
+	c.autosave()
+	if len(fake.timers) != 0 {
+		t.Error("salvamento agendado sem alterações")
+	}
+	for _, title := range []string{"deploy", "backup"} {
+		c.todoList.AddTask(title, "")
+		c.autosave()
+	}
+	if len(store.saved) != 0 || len(fake.timers) != 2 {
+		t.Fatalf("%d gravações e %d prazos antes do debounce vencer", len(store.saved), len(fake.timers))
+	}
+
+	input, writer := io.Pipe()
+	defer writer.Close()
+	c.scanner = bufio.NewScanner(input)
+	line := make(chan string)
+	go func() {
+		read, _ := c.tryReadInput("")
+		line <- read
+	}()
+
+	fake.fire()
+	select {
+	case tasks := <-store.saved:
+		if tasks != 2 {
+			t.Errorf("gravadas %d tarefas, esperado 2", tasks)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("o prazo venceu e nada foi gravado")
+	}
+	io.WriteString(writer, "2\n")
+	if read := <-line; read != "2" {
+		t.Errorf("linha lida %q, esperado \"2\"", read)
+	}
+	if c.dirty() || c.debounce.C() != nil {
+		t.Error("salvamento ainda pendente após gravar")
+	}
+
+	// Salvar pelo menu cancela o prazo pendente
+	c.todoList.AddTask("relatório", "")
+	c.autosave()
+	if err := c.persist(); err != nil {
+		t.Fatal(err)
+	}
+	if c.debounce.C() != nil {
+		t.Error("prazo mantido após salvar")
+	}
+}
+
+// Sem debounce, cada ação do menu grava na hora
+func TestAutosaveWithoutDebounce(t *testing.T) {
+	c, store := memCLI(t)
+	c.todoList.AddTask("deploy", "")
+	c.autosave()
+	if len(store.saved) != 1 {
+		t.Errorf("%d gravações, esperado 1", len(store.saved))
+	}
+}
+
+// O fim da entrada (Ctrl-D) grava o que estava pendente e encerra com
+// sucesso, mesmo com o debounce ainda contando
+func TestShutdownOnEOF(t *testing.T) {
+	c, store := memCLI(t, WithAutosaveDelay(time.Hour))
+	c.interactive = true
+	c.scanner = bufio.NewScanner(strings.NewReader(""))
+	c.todoList.AddTask("deploy", "")
+	c.autosave()
+
+	if code := exited(t, c, func() { c.readInput("") }); code != ExitOK {
+		t.Errorf("código %d, esperado %d", code, ExitOK)
+	}
+	if len(store.saved) != 1 || c.dirty() {
+		t.Errorf("%d gravações ao encerrar, esperado 1", len(store.saved))
+	}
+}
+
+// Um sinal durante a espera grava e encerra com 128 + o número do sinal
+func TestShutdownOnSignal(t *testing.T) {
+	c, store := memCLI(t)
+	input, writer := io.Pipe()
+	defer writer.Close()
+	c.scanner = bufio.NewScanner(input)
+	c.signals = make(chan os.Signal, 1)
+	c.todoList.AddTask("deploy", "")
+
+	c.signals <- syscall.SIGTERM
+	if code := exited(t, c, func() { c.readInput("") }); code != 128+int(syscall.SIGTERM) {
+		t.Errorf("código %d, esperado %d", code, 128+int(syscall.SIGTERM))
+	}
+	if len(store.saved) != 1 {
+		t.Errorf("%d gravações ao encerrar, esperado 1", len(store.saved))
+	}
+
+	// Sem alterações pendentes, nada é gravado
+	c.signals <- os.Interrupt
+	if code := exited(t, c, func() { c.readInput("") }); code != 128+int(syscall.SIGINT) {
+		t.Errorf("código %d após Ctrl-C", code)
+	}
+	if len(store.saved) != 1 {
+		t.Errorf("%d gravações, esperado 1", len(store.saved))
+	}
+}
+
+// Se a gravação falha ao encerrar, o código de saída indica o erro
+func TestShutdownSaveError(t *testing.T) {
+	c, store := memCLI(t)
+	store.err = errors.New("disco cheio")
+	c.todoList.AddTask("deploy", "")
+
+	if code := exited(t, c, func() { c.shutdown("Fim da entrada", ExitOK) }); code != ExitError {
+		t.Errorf("código %d, esperado %d", code, ExitError)
+	}
+}
@@ -2,29 +2,99 @@ package cli
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
 	"os"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/lucianoZgabriel/go-cli-todo/internal/storage"
 	"github.com/lucianoZgabriel/go-cli-todo/internal/task"
 )
 
+// defaultAgendaDays é o número de dias exibidos pela agenda
+const defaultAgendaDays = 7
+
+// allProjects indica que nenhum projeto está ativo e todas as tarefas
+// (exceto as de projetos arquivados) são exibidas
+const allProjects = -1
+
 // CLI representa a interface de linha de comando
 type CLI struct {
 	todoList *task.TodoList
 	storage  storage.Storage
 	scanner  *bufio.Scanner
+	project  int // ID do projeto ativo ou allProjects
+
+	// interactive indica que a CLI está no menu e pode fazer perguntas
+	interactive bool
+
+	// color indica que a saída aceita destaques ANSI (veja highlight.go)
+	color bool
+
+	// trashRetentionDays é o prazo até a lixeira ser limpa automaticamente
+	trashRetentionDays int
+
+	// history guarda as operações que podem ser desfeitas (veja history.go)
+	history *task.History
+
+	// Estado do salvamento automático (veja autosave.go)
+	debounce  *debouncer    // salvamento adiado até a lista parar de mudar
+	saved     []byte        // conteúdo da lista no último Load/Save
+	lines     chan string   // linhas lidas da entrada padrão
+	wantLine  chan struct{} // pede a leitura da próxima linha
+	inputDone bool          // a entrada padrão chegou ao fim
+	signals   chan os.Signal
+	exit      func(code int) // encerra o programa (os.Exit; trocado nos testes)
+}
+
+// Option configura uma CLI criada por NewCLI
+type Option func(*CLI)
+
+// WithAutosaveDelay define o debounce do salvamento automático: as
+// alterações são gravadas depois de delay sem novas alterações. Zero
+// grava imediatamente após cada ação.
+func WithAutosaveDelay(delay time.Duration) Option {
+	return func(c *CLI) {
+		c.debounce = newDebouncer(delay)
+	}
+}
+
+// WithTrashRetention define por quantos dias as tarefas removidas ficam na
+// lixeira. Zero desativa a limpeza automática.
+func WithTrashRetention(days int) Option {
+	return func(c *CLI) {
+		c.trashRetentionDays = days
+	}
+}
+
+// WithUndoDepth define quantas operações podem ser desfeitas. Zero
+// desativa o histórico.
+func WithUndoDepth(depth int) Option {
+	return func(c *CLI) {
+		c.history.SetDepth(depth)
+	}
 }
 
 // NewCLI cria uma nova instância da CLI
-func NewCLI(storage storage.Storage) *CLI {
-	return &CLI{
+func NewCLI(storage storage.Storage, opts ...Option) *CLI {
+	c := &CLI{
 		todoList: task.NewTodoList(),
 		storage:  storage,
 		scanner:  bufio.NewScanner(os.Stdin),
+		project:  allProjects,
+		history:  task.NewHistory(task.DefaultHistoryDepth),
+		color:    colorEnabled(),
+		debounce: newDebouncer(0),
+		exit:     os.Exit,
+
+		trashRetentionDays: task.DefaultTrashRetentionDays,
+	}
+	for _, opt := range opts {
+		opt(c)
 	}
+	return c
 }
 
 // Start inicia o loop principal da aplicação
@@ -34,10 +104,16 @@ func (c *CLI) Start() error {
 		return fmt.Errorf("erro ao carregar dados: %w", err)
 	}
 
+	c.interactive = true
+	c.handleSignals()
+
 	fmt.Println("=== 📋 Todo CLI ===")
 	fmt.Println("Bem-vindo ao seu gerenciador de tarefas!")
 
 	for {
+		// No menu as buscas se repetem e o índice compensa; a lista pode ter
+		// sido trocada (ex.: ao mesclar), então ele é religado a cada volta
+		c.todoList.EnableSearchIndex()
 		c.displayMenu()
 		choice := c.readInput("Escolha uma opção: ")
 
@@ -46,6 +122,7 @@ func (c *CLI) Start() error {
 				break
 			}
 		}
+		c.autosave()
 	}
 
 	fmt.Println("👋 Até mais!")
@@ -55,11 +132,14 @@ func (c *CLI) Start() error {
 
 // displayMenu mostra o menu principal
 func (c *CLI) displayMenu() {
-	total, completed, pending := c.todoList.Stats()
+	scope := c.scope()
+	total, completed, pending := scope.Stats()
+	overdue := scope.CountOverdue(time.Now())
 
 	fmt.Printf("\n=== MENU PRINCIPAL ===\n")
-	fmt.Printf("📊 Status: %d total | ✅ %d concluídas | ⏳ %d pendentes\n\n",
-		total, completed, pending)
+	fmt.Printf("📁 Projeto: %s\n", c.projectLabel())
+	fmt.Printf("📊 Status: %d total | ✅ %d concluídas | ⏳ %d pendentes | ⚠️  %d atrasadas\n\n",
+		total, completed, pending, overdue)
 
 	fmt.Println("1. 📝 Adicionar tarefa")
 	fmt.Println("2. 📋 Listar todas as tarefas")
@@ -69,6 +149,22 @@ func (c *CLI) displayMenu() {
 	fmt.Println("6. 🔍 Buscar tarefas")
 	fmt.Println("7. ⏳ Listar tarefas pendentes")
 	fmt.Println("8. 💾 Salvar e sair")
+	fmt.Println("9. 📅 Agenda")
+	fmt.Println("10. 🏷️  Gerenciar tags de uma tarefa")
+	fmt.Println("11. 🔖 Filtrar tarefas por tag")
+	fmt.Println("12. 📊 Estatísticas")
+	fmt.Println("13. 📁 Projetos")
+	fmt.Println("14. 🌳 Adicionar subtarefa")
+	fmt.Println("15. ⛓️  Gerenciar dependências")
+	fmt.Println("16. 🚀 Listar tarefas prontas")
+	fmt.Println("17. ↩️  Desfazer")
+	fmt.Println("18. ↪️  Refazer")
+	fmt.Println("19. 🗑️  Lixeira")
+	fmt.Println("20. ✏️  Editar tarefa")
+	fmt.Println("21. 📝 Editar tarefa no editor")
+	fmt.Println("22. 🔎 Visões salvas")
+	fmt.Println("23. ↕️  Reordenar tarefa")
+	c.displayPinnedViews()
 	fmt.Printf("\n")
 }
 
@@ -78,27 +174,62 @@ func (c *CLI) handleMenuChoice(choice string) error {
 
 	switch choice {
 	case "1":
-		err = c.addTask()
+		err = c.record(c.addTask)
 	case "2":
-		err = c.listAllTasks()
+		err = c.record(c.listAllTasks)
 	case "3":
-		err = c.toggleTaskCompleted(true)
+		err = c.record(func() error { return c.toggleTaskCompleted(true) })
 	case "4":
-		err = c.toggleTaskCompleted(false)
+		err = c.record(func() error { return c.toggleTaskCompleted(false) })
 	case "5":
-		err = c.removeTask()
+		err = c.record(c.removeTask)
 	case "6":
-		err = c.searchTasks()
+		err = c.record(c.searchTasks)
 	case "7":
-		err = c.listPendingTasks()
+		err = c.record(c.listPendingTasks)
 	case "8":
-		if err := c.saveData(); err != nil {
-			return fmt.Errorf("erro ao salvar: %w", err)
+		if err := c.persist(); err != nil {
+			fmt.Printf("❌ Erro ao salvar: %s\n", err)
+			return nil
 		}
 		fmt.Println("💾 Dados salvos com sucesso!")
 		return fmt.Errorf("exit") // Signal to exit
+	case "9":
+		err = c.showAgenda()
+	case "10":
+		err = c.record(c.manageTags)
+	case "11":
+		err = c.listTasksByTag()
+	case "12":
+		err = c.showStatistics()
+	case "13":
+		err = c.record(c.manageProjects)
+	case "14":
+		err = c.record(c.addSubtask)
+	case "15":
+		err = c.record(c.manageDependencies)
+	case "16":
+		err = c.record(c.listReadyTasks)
+	case "17":
+		err = c.undo()
+	case "18":
+		err = c.redo()
+	case "19":
+		err = c.record(c.manageTrash)
+	case "20":
+		err = c.record(c.editTask)
+	case "21":
+		err = c.record(c.editTaskInEditor)
+	case "22":
+		err = c.record(c.manageViews)
+	case "23":
+		err = c.record(c.moveTask)
 	default:
-		return fmt.Errorf("opção inválida: %s", choice)
+		view, ok := c.pinnedViewChoice(choice)
+		if !ok {
+			return fmt.Errorf("opção inválida: %s", choice)
+		}
+		err = c.showView(view, nil)
 	}
 
 	// Se houve erro, mostra e pausa
@@ -111,13 +242,128 @@ func (c *CLI) handleMenuChoice(choice string) error {
 	return nil
 }
 
-// readInput lê uma linha de input do usuário
+// scope retorna as tarefas visíveis no projeto ativo. A lista retornada
+// é uma cópia para consulta; alterações devem usar c.todoList.
+func (c *CLI) scope() *task.TodoList {
+	if c.project == allProjects {
+		return c.todoList.ActiveView()
+	}
+	return c.todoList.ProjectView(c.project)
+}
+
+// inScope filtra tarefas obtidas da lista completa, mantendo apenas as
+// visíveis no projeto ativo. Útil para consultas que dependem de tarefas
+// de outros projetos, como dependências.
+func (c *CLI) inScope(tasks []task.Task) []task.Task {
+	visible := make(map[int]bool)
+	for _, t := range c.scope().Tasks {
+		visible[t.ID] = true
+	}
+
+	var filtered []task.Task
+	for _, t := range tasks {
+		if visible[t.ID] {
+			filtered = append(filtered, t)
+		}
+	}
+	return filtered
+}
+
+// useProject define o projeto ativo pelo nome; vazio ativa todos os projetos
+func (c *CLI) useProject(name string) error {
+	if strings.TrimSpace(name) == "" {
+		c.project = allProjects
+		return nil
+	}
+
+	project, err := c.todoList.FindProject(name)
+	if err != nil {
+		return err
+	}
+	c.project = project.ID
+	return nil
+}
+
+// projectLabel retorna o nome do projeto ativo para exibição
+func (c *CLI) projectLabel() string {
+	if c.project == allProjects {
+		return "todos"
+	}
+	return c.todoList.ProjectName(c.project)
+}
+
+// targetProject retorna o projeto em que novas tarefas devem ser criadas
+func (c *CLI) targetProject() string {
+	if c.project == allProjects {
+		return task.DefaultProjectName
+	}
+	return c.todoList.ProjectName(c.project)
+}
+
+// readInput lê uma linha de input do usuário. Enquanto espera, atende os
+// sinais de encerramento e o salvamento adiado pelo debounce. O fim da
+// entrada (Ctrl-D) salva e encerra o programa.
 func (c *CLI) readInput(prompt string) string {
+	line, ok := c.tryReadInput(prompt)
+	if !ok {
+		fmt.Println()
+		c.shutdown("Fim da entrada", ExitOK)
+	}
+	return line
+}
+
+// tryReadInput lê uma linha como readInput, mas no fim da entrada retorna
+// false em vez de encerrar o programa
+func (c *CLI) tryReadInput(prompt string) (string, bool) {
 	fmt.Print(prompt)
-	if c.scanner.Scan() {
-		return strings.TrimSpace(c.scanner.Text())
+	if c.inputDone {
+		return "", false
+	}
+	lines := c.input()
+	c.wantLine <- struct{}{}
+	for {
+		select {
+		case line, ok := <-lines:
+			if !ok {
+				c.inputDone = true
+				return "", false
+			}
+			return strings.TrimSpace(line), true
+		case sig := <-c.signals:
+			fmt.Println()
+			c.shutdown(fmt.Sprintf("Sinal recebido (%v)", sig), signalExitCode(sig))
+		case <-c.debounce.C():
+			c.debounce.cancel()
+			c.flush(false)
+		}
 	}
-	return ""
+}
+
+// input retorna o canal de linhas da entrada padrão, iniciando a leitura
+// na primeira chamada. Cada linha só é lida quando pedida em wantLine, para
+// que a entrada fique livre para outros programas (como o editor externo)
+// enquanto a CLI não espera por ela. O canal é fechado no fim da entrada.
+func (c *CLI) input() <-chan string {
+	if c.lines == nil {
+		c.lines = make(chan string)
+		c.wantLine = make(chan struct{}, 1)
+		go func() {
+			defer close(c.lines)
+			for range c.wantLine {
+				if !c.scanner.Scan() {
+					return
+				}
+				c.lines <- c.scanner.Text()
+			}
+		}()
+	}
+	return c.lines
+}
+
+// isTerminal informa se o arquivo é um terminal, e não um pipe ou arquivo
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
 }
 
 // readInt lê um número inteiro do usuário
@@ -135,10 +381,63 @@ func (c *CLI) readInt(prompt string) (int, error) {
 	return num, nil
 }
 
+// readDue lê um prazo opcional do usuário. Entrada vazia retorna prazo nil.
+func (c *CLI) readDue(prompt string) (*time.Time, bool, error) {
+	input := c.readInput(prompt)
+	if input == "" {
+		return nil, false, nil
+	}
+
+	due, hasTime, err := task.ParseDue(input, time.Now())
+	if err != nil {
+		return nil, false, err
+	}
+	return &due, hasTime, nil
+}
+
+// parseQuery interpreta uma consulta de busca. Erros de sintaxe indicam,
+// abaixo da consulta, onde está o problema.
+func parseQuery(input string) (*task.Query, error) {
+	query, err := task.ParseQuery(input, time.Now())
+	if err != nil {
+		return nil, describeQueryError(err)
+	}
+	return query, nil
+}
+
+// describeQueryError acrescenta a um erro de sintaxe de consulta a
+// indicação de onde está o problema
+func describeQueryError(err error) error {
+	var queryErr *task.QueryError
+	if errors.As(err, &queryErr) {
+		return usagef("%v\n   %s", err, strings.ReplaceAll(queryErr.Pointer(), "\n", "\n   "))
+	}
+	return err
+}
+
+// parseTagList interpreta uma lista de tags separadas por espaço ou vírgula
+func parseTagList(input string) ([]string, error) {
+	fields := strings.FieldsFunc(input, func(r rune) bool {
+		return r == ',' || r == ' '
+	})
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("nenhuma tag informada")
+	}
+
+	tags := make([]string, 0, len(fields))
+	for _, field := range fields {
+		tag, err := task.NormalizeTag(field)
+		if err != nil {
+			return nil, err
+		}
+		tags = append(tags, tag)
+	}
+	return tags, nil
+}
+
 // waitForEnter pausa até o usuário pressionar Enter
 func (c *CLI) waitForEnter() {
-	fmt.Print("\n🔄 Pressione Enter para continuar...")
-	c.scanner.Scan()
+	c.readInput("\n🔄 Pressione Enter para continuar...")
 }
 
 // loadData carrega dados do storage
@@ -148,11 +447,15 @@ func (c *CLI) loadData() error {
 		return err
 	}
 
+	if r, ok := c.storage.(storage.Recoverer); ok {
+		if err := r.Recovered(); err != nil {
+			fmt.Fprintf(os.Stderr, "⚠️  %v\n", err)
+		}
+	}
+
 	c.todoList = todoList
+	c.saved = c.snapshot()
+	c.loadHistory()
+	c.autoPurgeTrash()
 	return nil
 }
-
-// saveData salva dados no storage
-func (c *CLI) saveData() error {
-	return c.storage.Save(c.todoList)
-}
@@ -0,0 +1,1384 @@
+package cli
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/lucianoZgabriel/go-cli-todo/internal/output"
+	"github.com/lucianoZgabriel/go-cli-todo/internal/storage"
+	"github.com/lucianoZgabriel/go-cli-todo/internal/task"
+)
+
+// Códigos de saída do modo não interativo
+const (
+	ExitOK       = 0 // comando executado com sucesso
+	ExitError    = 1 // falha genérica (ex.: erro de leitura/escrita do storage)
+	ExitUsage    = 2 // comando, flag ou argumento inválido
+	ExitNotFound = 3 // a tarefa, o projeto ou a visão informada não existe
+	ExitBlocked  = 4 // operação impedida pelo estado da tarefa (ex.: subtarefas, ciclo de dependências)
+)
+
+// usageError representa um erro de uso da linha de comando
+type usageError struct {
+	msg string
+}
+
+func (e *usageError) Error() string {
+	return e.msg
+}
+
+// usagef cria um erro de uso formatado
+func usagef(format string, args ...any) error {
+	return &usageError{msg: fmt.Sprintf(format, args...)}
+}
+
+// command descreve um subcomando do modo não interativo
+type command struct {
+	name    string
+	usage   string
+	summary string
+	// mutates indica se o comando sempre altera a lista e precisa salvar.
+	// Comandos que só às vezes alteram (como "view") registram a alteração
+	// no histórico por conta própria e são salvos por ela ter mudado a lista.
+	mutates bool
+	run     func(c *CLI, fs *flag.FlagSet, args []string) error
+}
+
+// commands lista os subcomandos disponíveis, na ordem exibida na ajuda
+var commands = []command{
+	{"add", "add [-d descrição] [-p prioridade] [--due prazo] [--every regra] [--project nome | --parent id] <título>", "adiciona uma tarefa", true, (*CLI).cmdAdd},
+	{"list", "list [--pending | --done] [--tag tags] [--project nome] [--filter consulta] [--sort ordem] [-v] [-o formato]", "lista as tarefas", false, (*CLI).cmdList},
+	{"show", "show <id> [-o formato]", "mostra todos os detalhes de uma tarefa", false, (*CLI).cmdShow},
+	{"done", "done [--cascade] <id>...", "marca tarefas como concluídas", true, (*CLI).cmdDone},
+	{"reopen", "reopen <id>...", "marca tarefas como pendentes", true, (*CLI).cmdReopen},
+	{"rm", "rm [--cascade] <id>...", "move tarefas para a lixeira", true, (*CLI).cmdRemove},
+	{"edit", "edit <id> [-t título] [-d descrição] [-p prioridade] [--due prazo] [--every regra] [--tags tags] [--project nome] [--editor]", "edita uma tarefa", true, (*CLI).cmdEdit},
+	{"block", "block <id> <id-bloqueadora>...", "registra que a tarefa depende de outras", true, (*CLI).cmdBlock},
+	{"unblock", "unblock <id> <id-bloqueadora>...", "remove dependências da tarefa", true, (*CLI).cmdUnblock},
+	{"move", "move <id> --before <id> | --top | --bottom", "muda a posição da tarefa na ordem manual", true, (*CLI).cmdMove},
+	{"ready", "ready [--project nome] [--sort ordem]", "lista tarefas pendentes sem dependências em aberto", false, (*CLI).cmdReady},
+	{"tag", "tag <id> <tag>...", "adiciona tags a uma tarefa", true, (*CLI).cmdTag},
+	{"untag", "untag <id> <tag>...", "remove tags de uma tarefa", true, (*CLI).cmdUntag},
+	{"series", "series <id>", "mostra o histórico de ocorrências de uma tarefa recorrente", false, (*CLI).cmdSeries},
+	{"history", "history <id>", "mostra o histórico de alterações de uma tarefa", false, (*CLI).cmdHistory},
+	{"search", "search [--tag tags] [--project nome] [--sort ordem] [-o formato] <consulta>", "busca tarefas, das mais às menos relevantes (ex.: deploy tag:infra status:pending -wip)", false, (*CLI).cmdSearch},
+	{"agenda", "agenda [--days N] [--project nome]", "mostra tarefas atrasadas e próximas do prazo", false, (*CLI).cmdAgenda},
+	{"stats", "stats [--project nome] [-o formato]", "mostra estatísticas", false, (*CLI).cmdStats},
+	{"project", "project list|add|rename|archive|unarchive|move ...", "gerencia projetos", true, (*CLI).cmdProject},
+	{"view", "view [list] | <nome> [--project nome] [--sort ordem] [-o formato] | save [--pin] [--sort ordem] <nome> <consulta> | rm|pin|unpin <nome>", "executa e gerencia visões salvas", false, (*CLI).cmdView},
+	{"sort", "sort [<listagem> [<ordenação> | --reset]]", "mostra ou altera a ordenação padrão das listagens (list, pending, search, ready)", false, (*CLI).cmdSort},
+	{"trash", "trash", "lista as tarefas da lixeira", false, (*CLI).cmdTrash},
+	{"restore", "restore <id>...", "restaura tarefas da lixeira", true, (*CLI).cmdRestore},
+	{"purge", "purge [--older-than dias] [<id>...]", "apaga definitivamente tarefas da lixeira", true, (*CLI).cmdPurge},
+	{"undo", "undo [N]", "desfaz as últimas N operações (padrão: 1)", true, (*CLI).cmdUndo},
+	{"redo", "redo [N]", "refaz as últimas N operações desfeitas (padrão: 1)", true, (*CLI).cmdRedo},
+	{"migrate", "migrate [--dry-run]", "atualiza os dados gravados para o formato atual", false, (*CLI).cmdMigrate},
+}
+
+// historyCommands lista os comandos que navegam pelo histórico e, por
+// isso, não são registrados nele como novas operações
+var historyCommands = map[string]bool{
+	"undo": true,
+	"redo": true,
+}
+
+// unloadedCommands lista os comandos que acessam o storage diretamente, sem
+// carregar a lista antes (o carregamento já aplicaria as migrações)
+var unloadedCommands = map[string]bool{
+	"migrate": true,
+}
+
+// Run executa um subcomando a partir dos argumentos da linha de comando
+// e retorna o código de saída do processo
+func (c *CLI) Run(args []string) int {
+	if len(args) == 0 || args[0] == "help" || args[0] == "-h" || args[0] == "--help" {
+		c.printUsage(os.Stdout)
+		return ExitOK
+	}
+
+	cmd, ok := findCommand(args[0])
+	if !ok {
+		fmt.Fprintf(os.Stderr, "❌ Comando desconhecido: %s\n\n", args[0])
+		c.printUsage(os.Stderr)
+		return ExitUsage
+	}
+
+	if !unloadedCommands[cmd.name] {
+		if err := c.loadData(); err != nil {
+			fmt.Fprintf(os.Stderr, "❌ Erro ao carregar dados: %v\n", err)
+			return ExitError
+		}
+	}
+
+	run := func() error { return cmd.run(c, newFlagSet(cmd), args[1:]) }
+	if cmd.mutates && !historyCommands[cmd.name] {
+		action := run
+		run = func() error { return c.record(action) }
+	}
+
+	if err := run(); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return ExitOK
+		}
+		fmt.Fprintf(os.Stderr, "❌ Erro: %v\n", err)
+		return exitCode(err)
+	}
+
+	// a limpeza automática da lixeira no carregamento também precisa ser salva
+	if cmd.mutates || (!unloadedCommands[cmd.name] && c.dirty()) {
+		if err := c.saveData(); err != nil {
+			fmt.Fprintf(os.Stderr, "❌ Erro ao salvar: %v\n", err)
+			return ExitError
+		}
+	}
+
+	return ExitOK
+}
+
+// findCommand busca um subcomando pelo nome
+func findCommand(name string) (command, bool) {
+	for _, cmd := range commands {
+		if cmd.name == name {
+			return cmd, true
+		}
+	}
+	return command{}, false
+}
+
+// exitCode traduz um erro no código de saída correspondente
+func exitCode(err error) int {
+	var usageErr *usageError
+	var fieldErr *task.FieldError
+	switch {
+	case errors.As(err, &usageErr), errors.As(err, &fieldErr):
+		return ExitUsage
+	case errors.Is(err, task.ErrTaskNotFound), errors.Is(err, task.ErrProjectNotFound),
+		errors.Is(err, task.ErrNotInTrash), errors.Is(err, task.ErrViewNotFound):
+		return ExitNotFound
+	case errors.Is(err, task.ErrOpenSubtasks), errors.Is(err, task.ErrHasSubtasks),
+		errors.Is(err, task.ErrDependencyCycle):
+		return ExitBlocked
+	default:
+		return ExitError
+	}
+}
+
+// printUsage exibe a ajuda geral do modo não interativo
+func (c *CLI) printUsage(w io.Writer) {
+	fmt.Fprintln(w, "Uso: todo [comando] [flags] [argumentos]")
+	fmt.Fprintln(w, "Sem comando, abre o menu interativo.")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Comandos:")
+
+	tw := tabwriter.NewWriter(w, 0, 0, 3, ' ', 0)
+	for _, cmd := range commands {
+		fmt.Fprintf(tw, "  %s\t%s\n", cmd.usage, cmd.summary)
+	}
+	tw.Flush()
+}
+
+// newFlagSet cria o conjunto de flags de um subcomando
+func newFlagSet(cmd command) *flag.FlagSet {
+	fs := flag.NewFlagSet(cmd.name, flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Uso: todo %s\n", cmd.usage)
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+// parseArgs processa as flags permitindo que apareçam antes ou depois
+// dos argumentos posicionais, que são retornados na ordem original
+func parseArgs(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			if errors.Is(err, flag.ErrHelp) {
+				return nil, err
+			}
+			return nil, &usageError{msg: err.Error()}
+		}
+
+		rest := fs.Args()
+		if consumed := len(args) - len(rest); consumed > 0 && args[consumed-1] == "--" {
+			return append(positional, rest...), nil
+		}
+		if len(rest) == 0 {
+			return positional, nil
+		}
+
+		positional = append(positional, rest[0])
+		args = rest[1:]
+	}
+}
+
+// parseIDs converte argumentos posicionais em IDs de tarefas
+func parseIDs(args []string) ([]int, error) {
+	if len(args) == 0 {
+		return nil, usagef("informe ao menos um ID")
+	}
+
+	ids := make([]int, 0, len(args))
+	for _, arg := range args {
+		id, err := strconv.Atoi(arg)
+		if err != nil {
+			return nil, usagef("ID inválido: %s", arg)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// cmdAdd implementa o subcomando "add"
+func (c *CLI) cmdAdd(fs *flag.FlagSet, args []string) error {
+	description := fs.String("d", "", "descrição da tarefa")
+	priorityName := fs.String("p", "", "prioridade: none, low, medium, high ou urgent")
+	dueInput := fs.String("due", "", "prazo: AAAA-MM-DD [HH:MM], DD/MM/AAAA [HH:MM], hoje ou amanhã")
+	projectName := fs.String("project", task.DefaultProjectName, "projeto da tarefa")
+	parentID := fs.Int("parent", 0, "cria a tarefa como subtarefa do ID informado")
+	rule := fs.String("every", "", "repetição: daily, weekly[:mon,fri], monthly[:15], every:3d, after:1w")
+
+	rest, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if *parentID != 0 {
+		// Subtarefas herdam o projeto da tarefa principal
+		fs.Visit(func(f *flag.Flag) {
+			if f.Name == "project" {
+				err = usagef("--project não pode ser usado com --parent")
+			}
+		})
+		if err != nil {
+			return err
+		}
+	}
+	if _, err := c.todoList.FindProject(*projectName); err != nil {
+		return err
+	}
+	if *parentID != 0 {
+		if _, err := c.todoList.GetTask(*parentID); err != nil {
+			return err
+		}
+	}
+
+	title := strings.TrimSpace(strings.Join(rest, " "))
+	if title == "" {
+		return usagef("título não pode ser vazio")
+	}
+
+	priority, err := task.ParsePriority(*priorityName)
+	if err != nil {
+		return usagef("%v", err)
+	}
+
+	due, hasTime, err := parseDueFlag(*dueInput)
+	if err != nil {
+		return err
+	}
+
+	recurrence, err := parseRecurrenceFlag(*rule)
+	if err != nil {
+		return err
+	}
+
+	var t *task.Task
+	if *parentID != 0 {
+		if t, err = c.todoList.AddSubtask(*parentID, title, *description); err != nil {
+			return err
+		}
+	} else {
+		t = c.todoList.AddTask(title, *description)
+		if err := c.todoList.MoveTask(t.ID, *projectName); err != nil {
+			return err
+		}
+	}
+	if err := c.todoList.SetPriority(t.ID, priority); err != nil {
+		return err
+	}
+	if err := c.todoList.SetDueDate(t.ID, due, hasTime); err != nil {
+		return err
+	}
+	if err := c.todoList.SetRecurrence(t.ID, recurrence); err != nil {
+		return err
+	}
+	fmt.Printf("✅ Tarefa [%d] criada: %s\n", t.ID, t.Title)
+	return nil
+}
+
+// cmdList implementa o subcomando "list"
+func (c *CLI) cmdList(fs *flag.FlagSet, args []string) error {
+	pendingOnly := fs.Bool("pending", false, "lista apenas tarefas pendentes")
+	doneOnly := fs.Bool("done", false, "lista apenas tarefas concluídas")
+	verbose := fs.Bool("v", false, "exibe todos os detalhes das tarefas")
+	tagInput := fs.String("tag", "", "lista apenas tarefas com todas as tags (separadas por vírgula)")
+	projectName := fs.String("project", "", "lista apenas tarefas do projeto")
+	filter := fs.String("filter", "", "lista apenas tarefas que satisfazem a consulta (ex.: 'tag:infra due<2026-11-01')")
+	sortInput := sortFlag(fs)
+	outputName := outputFlag(fs)
+
+	rest, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	format, err := parseOutput(*outputName)
+	if err != nil {
+		return err
+	}
+	query, err := parseQuery(*filter)
+	if err != nil {
+		return err
+	}
+	listing := "list"
+	if *pendingOnly {
+		listing = "pending"
+	}
+	spec, err := c.listingSort(listing, *sortInput)
+	if err != nil {
+		return err
+	}
+	if err := c.useProject(*projectName); err != nil {
+		return err
+	}
+	if len(rest) > 0 {
+		return usagef("argumento inesperado: %s", rest[0])
+	}
+	if *pendingOnly && *doneOnly {
+		return usagef("--pending e --done não podem ser usados juntos")
+	}
+
+	tasks := c.scope().SortedTasks()
+	if *tagInput != "" {
+		tags, err := parseTagList(*tagInput)
+		if err != nil {
+			return usagef("%v", err)
+		}
+		tasks = task.FilterByTags(tasks, tags)
+	}
+
+	var selected []task.Task
+	for _, t := range tasks {
+		if (*pendingOnly && t.Completed) || (*doneOnly && !t.Completed) {
+			continue
+		}
+		selected = append(selected, t)
+	}
+	selected = query.Filter(c.todoList, selected, time.Now())
+	spec.Sort(selected)
+
+	if format != output.Text {
+		return c.writeTasks(format, selected)
+	}
+	c.displayTree(selected, *verbose)
+	return nil
+}
+
+// cmdShow implementa o subcomando "show"
+func (c *CLI) cmdShow(fs *flag.FlagSet, args []string) error {
+	outputName := outputFlag(fs)
+
+	rest, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	format, err := parseOutput(*outputName)
+	if err != nil {
+		return err
+	}
+	if len(rest) != 1 {
+		return usagef("informe exatamente um ID")
+	}
+
+	ids, err := parseIDs(rest)
+	if err != nil {
+		return err
+	}
+	t, err := c.todoList.FindTask(ids[0])
+	if err != nil {
+		return err
+	}
+
+	if format != output.Text {
+		return output.WriteItem(os.Stdout, format, c.taskRecord(*t))
+	}
+	c.displayTask(t)
+	if t.IsDeleted() {
+		fmt.Printf("🗑️  Na lixeira desde: %s\n", t.DeletedAt.Format("02/01/2006 15:04"))
+	}
+	return nil
+}
+
+// cmdDone implementa o subcomando "done"
+func (c *CLI) cmdDone(fs *flag.FlagSet, args []string) error {
+	cascade := fs.Bool("cascade", false, "conclui também as subtarefas pendentes")
+
+	rest, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	return c.setCompleted(rest, true, *cascade)
+}
+
+// cmdReopen implementa o subcomando "reopen"
+func (c *CLI) cmdReopen(fs *flag.FlagSet, args []string) error {
+	rest, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	return c.setCompleted(rest, false, false)
+}
+
+// setCompleted define o status de conclusão das tarefas informadas.
+// Tarefas que já estão no status desejado são ignoradas. Com cascade,
+// as subtarefas pendentes são concluídas junto com a tarefa principal.
+func (c *CLI) setCompleted(args []string, completed, cascade bool) error {
+	ids, err := parseIDs(args)
+	if err != nil {
+		return err
+	}
+
+	for _, id := range ids {
+		t, err := c.todoList.GetTask(id)
+		if err != nil {
+			return err
+		}
+
+		if t.Completed == completed {
+			fmt.Printf("ℹ️  Tarefa [%d] já está %s\n", id, statusLabel(completed))
+			continue
+		}
+
+		openBlockers := c.todoList.OpenBlockers(id)
+		if cascade {
+			err = c.todoList.CompleteWithSubtasks(id)
+		} else {
+			err = c.todoList.ToggleTask(id)
+		}
+		if errors.Is(err, task.ErrOpenSubtasks) {
+			return fmt.Errorf("tarefa [%d]: %w (use --cascade para concluí-las)", id, err)
+		}
+		if err != nil {
+			return err
+		}
+		fmt.Printf("✅ Tarefa [%d] marcada como %s\n", id, statusLabel(completed))
+		if completed {
+			warnOpenBlockers(openBlockers)
+			c.reportNextOccurrence(id)
+		}
+	}
+
+	return nil
+}
+
+// cmdRemove implementa o subcomando "rm"
+func (c *CLI) cmdRemove(fs *flag.FlagSet, args []string) error {
+	cascade := fs.Bool("cascade", false, "remove também as subtarefas")
+
+	rest, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+
+	ids, err := parseIDs(rest)
+	if err != nil {
+		return err
+	}
+
+	for _, id := range ids {
+		if *cascade {
+			err = c.todoList.RemoveTaskTree(id)
+		} else {
+			err = c.todoList.RemoveTask(id)
+		}
+		if errors.Is(err, task.ErrHasSubtasks) {
+			return fmt.Errorf("tarefa [%d]: %w (use --cascade para removê-las)", id, err)
+		}
+		if err != nil {
+			return err
+		}
+		fmt.Printf("🗑️ Tarefa [%d] movida para a lixeira\n", id)
+	}
+
+	return nil
+}
+
+// cmdBlock implementa o subcomando "block"
+func (c *CLI) cmdBlock(fs *flag.FlagSet, args []string) error {
+	id, blockerIDs, err := parseDependencyArgs(fs, args)
+	if err != nil {
+		return err
+	}
+
+	for _, blockerID := range blockerIDs {
+		if err := c.todoList.AddDependency(id, blockerID); err != nil {
+			return err
+		}
+	}
+
+	fmt.Printf("⛓️  Tarefa [%d] depende de: %s\n", id, formatTaskRefs(c.todoList.Blockers(id)))
+	return nil
+}
+
+// cmdUnblock implementa o subcomando "unblock"
+func (c *CLI) cmdUnblock(fs *flag.FlagSet, args []string) error {
+	id, blockerIDs, err := parseDependencyArgs(fs, args)
+	if err != nil {
+		return err
+	}
+
+	for _, blockerID := range blockerIDs {
+		if err := c.todoList.RemoveDependency(id, blockerID); err != nil {
+			return err
+		}
+	}
+
+	fmt.Printf("⛓️  Dependências da tarefa [%d] atualizadas\n", id)
+	return nil
+}
+
+// parseDependencyArgs interpreta os argumentos "<id> <id-bloqueadora>..."
+func parseDependencyArgs(fs *flag.FlagSet, args []string) (int, []int, error) {
+	rest, err := parseArgs(fs, args)
+	if err != nil {
+		return 0, nil, err
+	}
+	if len(rest) < 2 {
+		return 0, nil, usagef("informe o ID da tarefa e ao menos um ID bloqueador")
+	}
+
+	ids, err := parseIDs(rest)
+	if err != nil {
+		return 0, nil, err
+	}
+	return ids[0], ids[1:], nil
+}
+
+// cmdMove implementa o subcomando "move"
+func (c *CLI) cmdMove(fs *flag.FlagSet, args []string) error {
+	beforeID := fs.Int("before", 0, "coloca a tarefa logo antes da tarefa com este ID")
+	top := fs.Bool("top", false, "coloca a tarefa no início da ordem manual")
+	bottom := fs.Bool("bottom", false, "coloca a tarefa no fim da ordem manual")
+
+	rest, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(rest) != 1 {
+		return usagef("informe exatamente um ID")
+	}
+	ids, err := parseIDs(rest)
+	if err != nil {
+		return err
+	}
+
+	positions := 0
+	for _, set := range []bool{*beforeID != 0, *top, *bottom} {
+		if set {
+			positions++
+		}
+	}
+	if positions != 1 {
+		return usagef("informe uma posição: --before <id>, --top ou --bottom")
+	}
+
+	id := ids[0]
+	switch {
+	case *top:
+		err = c.todoList.MoveTaskToTop(id)
+	case *bottom:
+		err = c.todoList.MoveTaskToBottom(id)
+	default:
+		err = c.todoList.MoveTaskBefore(id, *beforeID)
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("↕️  Tarefa [%d] reordenada!\n", id)
+	return nil
+}
+
+// cmdReady implementa o subcomando "ready"
+func (c *CLI) cmdReady(fs *flag.FlagSet, args []string) error {
+	projectName := fs.String("project", "", "lista apenas tarefas do projeto")
+	sortInput := sortFlag(fs)
+
+	rest, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(rest) > 0 {
+		return usagef("argumento inesperado: %s", rest[0])
+	}
+	spec, err := c.listingSort("ready", *sortInput)
+	if err != nil {
+		return err
+	}
+	if err := c.useProject(*projectName); err != nil {
+		return err
+	}
+
+	ready := c.inScope(c.todoList.ReadyTasks())
+	spec.Sort(ready)
+	for _, t := range ready {
+		c.displayTaskSummary(&t)
+	}
+	return nil
+}
+
+// cmdTag implementa o subcomando "tag"
+func (c *CLI) cmdTag(fs *flag.FlagSet, args []string) error {
+	id, tags, err := parseTagArgs(fs, args)
+	if err != nil {
+		return err
+	}
+
+	if err := c.todoList.AddTags(id, tags...); err != nil {
+		return err
+	}
+
+	t, _ := c.todoList.GetTask(id)
+	fmt.Printf("🏷️  Tarefa [%d]: %s\n", id, formatTags(t.Tags))
+	return nil
+}
+
+// cmdUntag implementa o subcomando "untag"
+func (c *CLI) cmdUntag(fs *flag.FlagSet, args []string) error {
+	id, tags, err := parseTagArgs(fs, args)
+	if err != nil {
+		return err
+	}
+
+	if err := c.todoList.RemoveTags(id, tags...); err != nil {
+		return err
+	}
+
+	t, _ := c.todoList.GetTask(id)
+	fmt.Printf("🏷️  Tarefa [%d]: %s\n", id, formatTags(t.Tags))
+	return nil
+}
+
+// parseTagArgs interpreta os argumentos "<id> <tag>..." dos comandos de tags
+func parseTagArgs(fs *flag.FlagSet, args []string) (int, []string, error) {
+	rest, err := parseArgs(fs, args)
+	if err != nil {
+		return 0, nil, err
+	}
+	if len(rest) < 2 {
+		return 0, nil, usagef("informe o ID e ao menos uma tag")
+	}
+
+	ids, err := parseIDs(rest[:1])
+	if err != nil {
+		return 0, nil, err
+	}
+
+	tags, err := parseTagList(strings.Join(rest[1:], " "))
+	if err != nil {
+		return 0, nil, usagef("%v", err)
+	}
+	return ids[0], tags, nil
+}
+
+// cmdEdit implementa o subcomando "edit". Só os campos informados
+// explicitamente são alterados.
+func (c *CLI) cmdEdit(fs *flag.FlagSet, args []string) error {
+	title := fs.String("t", "", "novo título")
+	description := fs.String("d", "", "nova descrição")
+	priorityName := fs.String("p", "", "nova prioridade: none, low, medium, high ou urgent")
+	due := fs.String("due", "", "novo prazo (vazio remove o prazo)")
+	rule := fs.String("every", "", "nova regra de repetição (vazio remove a repetição)")
+	tagList := fs.String("tags", "", "substitui as tags, separadas por vírgula (vazio remove todas)")
+	project := fs.String("project", "", "move a tarefa para o projeto")
+	useEditor := fs.Bool("editor", false, "abre a tarefa no editor ($VISUAL ou $EDITOR)")
+
+	rest, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(rest) != 1 {
+		return usagef("informe exatamente um ID")
+	}
+
+	ids, err := parseIDs(rest)
+	if err != nil {
+		return err
+	}
+
+	var update task.TaskUpdate
+	fs.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "editor":
+		case "t":
+			update.Title = title
+		case "d":
+			update.Description = description
+		case "p":
+			priority, parseErr := task.ParsePriority(*priorityName)
+			if parseErr != nil && err == nil {
+				err = usagef("%v", parseErr)
+			}
+			update.Priority = &priority
+		case "due":
+			update.Due = due
+		case "every":
+			update.Recurrence = rule
+		case "tags":
+			var tags []string
+			if strings.TrimSpace(*tagList) != "" {
+				var parseErr error
+				if tags, parseErr = parseTagList(*tagList); parseErr != nil && err == nil {
+					err = usagef("%v", parseErr)
+				}
+			}
+			update.Tags = &tags
+		case "project":
+			update.Project = project
+		}
+	})
+	if err != nil {
+		return err
+	}
+	if *useEditor {
+		if !update.IsEmpty() {
+			return usagef("--editor não pode ser combinado com outras flags")
+		}
+		return c.editInEditor(ids[0])
+	}
+	if update.IsEmpty() {
+		return usagef("informe ao menos uma das flags -t, -d, -p, --due, --every, --tags, --project ou --editor")
+	}
+
+	changes, err := c.todoList.UpdateTask(ids[0], update)
+	if err != nil {
+		return err
+	}
+	reportChanges(ids[0], changes)
+	return nil
+}
+
+// cmdSeries implementa o subcomando "series"
+func (c *CLI) cmdSeries(fs *flag.FlagSet, args []string) error {
+	rest, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(rest) != 1 {
+		return usagef("informe exatamente um ID")
+	}
+
+	ids, err := parseIDs(rest)
+	if err != nil {
+		return err
+	}
+
+	t, err := c.todoList.GetTask(ids[0])
+	if err != nil {
+		return err
+	}
+	if t.SeriesID == 0 {
+		return fmt.Errorf("tarefa [%d] não é recorrente", t.ID)
+	}
+
+	series := c.todoList.Series(t.ID)
+	fmt.Printf("📚 Série de '%s' (%d ocorrência(s)):\n", t.Title, len(series))
+	for _, occurrence := range series {
+		completedAt := ""
+		if occurrence.CompletedAt != nil {
+			completedAt = " 🏁 " + occurrence.CompletedAt.Format("02/01/2006 15:04")
+		}
+		status := "⏳"
+		if occurrence.Completed {
+			status = "✅"
+		}
+		fmt.Printf("  %s [%d] ⏰ %s%s\n", status, occurrence.ID, occurrence.FormatDue(), completedAt)
+	}
+	return nil
+}
+
+// cmdSearch implementa o subcomando "search"
+func (c *CLI) cmdSearch(fs *flag.FlagSet, args []string) error {
+	tagInput := fs.String("tag", "", "restringe a busca às tarefas com todas as tags (separadas por vírgula)")
+	projectName := fs.String("project", "", "restringe a busca ao projeto")
+	sortInput := sortFlag(fs)
+	outputName := outputFlag(fs)
+
+	rest, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	format, err := parseOutput(*outputName)
+	if err != nil {
+		return err
+	}
+	spec, err := c.listingSort("search", *sortInput)
+	if err != nil {
+		return err
+	}
+	if err := c.useProject(*projectName); err != nil {
+		return err
+	}
+
+	// "#tag" no termo também filtra por tag, mas exige aspas no shell
+	query := strings.TrimSpace(strings.Join(rest, " "))
+	if *tagInput != "" {
+		tags, err := parseTagList(*tagInput)
+		if err != nil {
+			return usagef("%v", err)
+		}
+		query = strings.TrimSpace(query + " " + formatTags(tags))
+	}
+	if query == "" {
+		return usagef("termo de busca não pode ser vazio")
+	}
+
+	parsed, err := parseQuery(query)
+	if err != nil {
+		return err
+	}
+	results := parsed.Rank(c.todoList, c.scope().Tasks, time.Now())
+	spec.SortResults(results)
+	if format != output.Text {
+		return c.writeResults(format, results)
+	}
+	for _, result := range results {
+		t := c.highlighted(result)
+		c.displayTaskSummary(&t)
+	}
+	return nil
+}
+
+// cmdAgenda implementa o subcomando "agenda"
+func (c *CLI) cmdAgenda(fs *flag.FlagSet, args []string) error {
+	days := fs.Int("days", defaultAgendaDays, "quantidade de dias exibidos, incluindo hoje")
+	projectName := fs.String("project", "", "mostra apenas tarefas do projeto")
+
+	rest, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if err := c.useProject(*projectName); err != nil {
+		return err
+	}
+	if len(rest) > 0 {
+		return usagef("argumento inesperado: %s", rest[0])
+	}
+	if *days < 1 {
+		return usagef("--days deve ser maior que zero")
+	}
+
+	c.displayAgenda(*days)
+	return nil
+}
+
+// cmdStats implementa o subcomando "stats"
+func (c *CLI) cmdStats(fs *flag.FlagSet, args []string) error {
+	projectName := fs.String("project", "", "mostra estatísticas apenas do projeto")
+	outputName := outputFlag(fs)
+
+	rest, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	format, err := parseOutput(*outputName)
+	if err != nil {
+		return err
+	}
+	if err := c.useProject(*projectName); err != nil {
+		return err
+	}
+	if len(rest) > 0 {
+		return usagef("argumento inesperado: %s", rest[0])
+	}
+
+	if format != output.Text {
+		return output.WriteItem(os.Stdout, format, c.statsRecord())
+	}
+	c.displayStatistics()
+	return nil
+}
+
+// cmdProject implementa o subcomando "project" e suas ações
+func (c *CLI) cmdProject(fs *flag.FlagSet, args []string) error {
+	all := fs.Bool("all", false, "inclui projetos arquivados na listagem")
+
+	rest, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(rest) == 0 {
+		return usagef("informe a ação: list, add, rename, archive, unarchive ou move")
+	}
+
+	action, params := rest[0], rest[1:]
+	expectParams := func(n int, usage string) error {
+		if len(params) != n {
+			return usagef("uso: todo project %s", usage)
+		}
+		return nil
+	}
+
+	switch action {
+	case "list":
+		if err := expectParams(0, "list [--all]"); err != nil {
+			return err
+		}
+		c.displayProjects(*all)
+	case "add":
+		if err := expectParams(1, "add <nome>"); err != nil {
+			return err
+		}
+		project, err := c.todoList.AddProject(params[0])
+		if err != nil {
+			return err
+		}
+		fmt.Printf("✅ Projeto '%s' criado\n", project.Name)
+	case "rename":
+		if err := expectParams(2, "rename <nome> <novo nome>"); err != nil {
+			return err
+		}
+		if err := c.todoList.RenameProject(params[0], params[1]); err != nil {
+			return err
+		}
+		fmt.Printf("✏️  Projeto '%s' renomeado para '%s'\n", params[0], params[1])
+	case "archive", "unarchive":
+		if err := expectParams(1, action+" <nome>"); err != nil {
+			return err
+		}
+		archive := action == "archive"
+		if err := c.todoList.SetProjectArchived(params[0], archive); err != nil {
+			return err
+		}
+		if archive {
+			fmt.Printf("📦 Projeto '%s' arquivado\n", params[0])
+		} else {
+			fmt.Printf("📂 Projeto '%s' desarquivado\n", params[0])
+		}
+	case "move":
+		if len(params) < 2 {
+			return usagef("uso: todo project move <projeto> <id>...")
+		}
+		ids, err := parseIDs(params[1:])
+		if err != nil {
+			return err
+		}
+		for _, id := range ids {
+			if err := c.todoList.MoveTask(id, params[0]); err != nil {
+				return err
+			}
+			fmt.Printf("🚚 Tarefa [%d] movida para '%s'\n", id, params[0])
+		}
+	default:
+		return usagef("ação desconhecida: %s", action)
+	}
+
+	return nil
+}
+
+// cmdView implementa o subcomando "view"
+func (c *CLI) cmdView(fs *flag.FlagSet, args []string) error {
+	pin := fs.Bool("pin", false, "fixa a visão salva no menu principal")
+	projectName := fs.String("project", "", "executa a visão apenas no projeto")
+	sortInput := sortFlag(fs)
+	outputName := outputFlag(fs)
+
+	rest, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	format, err := parseOutput(*outputName)
+	if err != nil {
+		return err
+	}
+	spec, err := parseSort(*sortInput)
+	if err != nil {
+		return err
+	}
+	if err := c.useProject(*projectName); err != nil {
+		return err
+	}
+	if len(rest) == 0 {
+		rest = []string{"list"}
+	}
+
+	action, params := rest[0], rest[1:]
+	expectName := func() error {
+		if len(params) != 1 {
+			return usagef("uso: todo view %s <nome>", action)
+		}
+		return nil
+	}
+
+	switch action {
+	case "list":
+		if len(params) > 0 {
+			return usagef("argumento inesperado: %s", params[0])
+		}
+		if len(c.todoList.Views) == 0 {
+			fmt.Println("📭 Nenhuma visão salva!")
+			return nil
+		}
+		c.displayViews()
+	case "save":
+		if len(params) < 2 {
+			return usagef("uso: todo view save [--pin] [--sort ordem] <nome> <consulta>")
+		}
+		return c.record(func() error {
+			return c.saveView(params[0], strings.Join(params[1:], " "), *sortInput, *pin)
+		})
+	case "rm":
+		if err := expectName(); err != nil {
+			return err
+		}
+		return c.record(func() error {
+			view, err := c.todoList.FindView(params[0])
+			if err != nil {
+				return err
+			}
+			if err := c.todoList.RemoveView(view.Name); err != nil {
+				return err
+			}
+			fmt.Printf("🗑️  Visão '%s' apagada\n", view.Name)
+			return nil
+		})
+	case "pin", "unpin":
+		if err := expectName(); err != nil {
+			return err
+		}
+		return c.record(func() error {
+			if err := c.todoList.SetViewPinned(params[0], action == "pin"); err != nil {
+				return err
+			}
+			if action == "pin" {
+				fmt.Printf("📌 Visão '%s' fixada no menu\n", params[0])
+			} else {
+				fmt.Printf("📍 Visão '%s' desafixada do menu\n", params[0])
+			}
+			return nil
+		})
+	default:
+		if len(params) > 0 {
+			return usagef("argumento inesperado: %s", params[0])
+		}
+		view, err := c.todoList.FindView(action)
+		if err != nil {
+			return err
+		}
+		if format == output.Text {
+			return c.showView(view, spec)
+		}
+		results, err := c.viewTasks(view, spec)
+		if err != nil {
+			return err
+		}
+		tasks := make([]task.Task, len(results))
+		for i, result := range results {
+			tasks[i] = result.Task
+		}
+		return c.writeTasks(format, tasks)
+	}
+
+	return nil
+}
+
+// cmdSort implementa o subcomando "sort"
+func (c *CLI) cmdSort(fs *flag.FlagSet, args []string) error {
+	reset := fs.Bool("reset", false, "volta a listagem à ordem padrão")
+
+	rest, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(rest) == 0 {
+		if *reset {
+			return usagef("uso: todo sort --reset <listagem>")
+		}
+		for _, listing := range task.Listings {
+			c.displayListingSort(listing)
+		}
+		return nil
+	}
+
+	listing, params := rest[0], rest[1:]
+	if !slices.Contains(task.Listings, listing) {
+		return usagef("listagem desconhecida '%s' (use %s)", listing, strings.Join(task.Listings, ", "))
+	}
+	switch {
+	case *reset && len(params) > 0:
+		return usagef("--reset não pode ser usado com uma ordenação")
+	case len(params) > 1:
+		return usagef("argumento inesperado: %s", params[1])
+	case !*reset && len(params) == 0:
+		c.displayListingSort(listing)
+		return nil
+	}
+
+	input := ""
+	if !*reset {
+		if _, err := parseSort(params[0]); err != nil {
+			return err
+		}
+		input = params[0]
+	}
+	return c.record(func() error {
+		if err := c.todoList.SetListingSort(listing, input); err != nil {
+			return err
+		}
+		if saved := c.todoList.ListingSort(listing); saved != nil {
+			fmt.Printf("↕️  Ordenação padrão de '%s': %s\n", listing, saved)
+		} else {
+			fmt.Printf("↕️  '%s' voltou à ordem padrão\n", listing)
+		}
+		return nil
+	})
+}
+
+// cmdUndo implementa o subcomando "undo"
+func (c *CLI) cmdUndo(fs *flag.FlagSet, args []string) error {
+	count, err := parseCount(fs, args)
+	if err != nil {
+		return err
+	}
+	for i := 0; i < count; i++ {
+		err := c.undo()
+		if errors.Is(err, task.ErrNothingToUndo) && i > 0 {
+			fmt.Printf("ℹ️  %s\n", err)
+			break
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// cmdRedo implementa o subcomando "redo"
+func (c *CLI) cmdRedo(fs *flag.FlagSet, args []string) error {
+	count, err := parseCount(fs, args)
+	if err != nil {
+		return err
+	}
+	for i := 0; i < count; i++ {
+		err := c.redo()
+		if errors.Is(err, task.ErrNothingToRedo) && i > 0 {
+			fmt.Printf("ℹ️  %s\n", err)
+			break
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// cmdHistory implementa o subcomando "history"
+func (c *CLI) cmdHistory(fs *flag.FlagSet, args []string) error {
+	rest, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(rest) != 1 {
+		return usagef("informe exatamente um ID")
+	}
+
+	ids, err := parseIDs(rest)
+	if err != nil {
+		return err
+	}
+
+	t, err := c.todoList.FindTask(ids[0])
+	if err != nil {
+		return err
+	}
+	c.displayTimeline(t)
+	return nil
+}
+
+// cmdTrash implementa o subcomando "trash"
+func (c *CLI) cmdTrash(fs *flag.FlagSet, args []string) error {
+	rest, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(rest) > 0 {
+		return usagef("argumento inesperado: %s", rest[0])
+	}
+
+	if len(c.todoList.Trash) == 0 {
+		fmt.Println("📭 A lixeira está vazia!")
+		return nil
+	}
+	c.displayTrash()
+	return nil
+}
+
+// cmdRestore implementa o subcomando "restore"
+func (c *CLI) cmdRestore(fs *flag.FlagSet, args []string) error {
+	rest, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+
+	ids, err := parseIDs(rest)
+	if err != nil {
+		return err
+	}
+
+	for _, id := range ids {
+		restored, err := c.todoList.RestoreTask(id)
+		if err != nil {
+			return err
+		}
+		reportRestored(restored)
+	}
+	return nil
+}
+
+// cmdPurge implementa o subcomando "purge". Sem IDs, apaga as tarefas
+// removidas há mais de --older-than dias (padrão: todas).
+func (c *CLI) cmdPurge(fs *flag.FlagSet, args []string) error {
+	olderThan := fs.Int("older-than", 0, "apaga apenas as tarefas removidas há mais de N dias")
+
+	rest, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if *olderThan < 0 {
+		return usagef("quantidade de dias inválida: %d", *olderThan)
+	}
+
+	if len(rest) == 0 {
+		var before time.Time
+		if *olderThan > 0 {
+			before = time.Now().AddDate(0, 0, -*olderThan)
+		}
+		purged := c.todoList.PurgeTrash(before)
+		fmt.Printf("🧹 %d tarefa(s) apagada(s) definitivamente\n", purged)
+		return nil
+	}
+
+	if *olderThan > 0 {
+		return usagef("--older-than não pode ser combinado com IDs")
+	}
+	ids, err := parseIDs(rest)
+	if err != nil {
+		return err
+	}
+	for _, id := range ids {
+		if err := c.todoList.PurgeTask(id); err != nil {
+			return err
+		}
+		fmt.Printf("❌ Tarefa [%d] apagada definitivamente\n", id)
+	}
+	return nil
+}
+
+// parseCount interpreta o argumento opcional N de undo/redo
+func parseCount(fs *flag.FlagSet, args []string) (int, error) {
+	rest, err := parseArgs(fs, args)
+	if err != nil {
+		return 0, err
+	}
+
+	switch len(rest) {
+	case 0:
+		return 1, nil
+	case 1:
+		count, err := strconv.Atoi(rest[0])
+		if err != nil || count < 1 {
+			return 0, usagef("quantidade inválida: %s", rest[0])
+		}
+		return count, nil
+	default:
+		return 0, usagef("argumento inesperado: %s", rest[1])
+	}
+}
+
+// cmdMigrate implementa o subcomando "migrate"
+func (c *CLI) cmdMigrate(fs *flag.FlagSet, args []string) error {
+	dryRun := fs.Bool("dry-run", false, "apenas mostra o que seria alterado, sem gravar")
+
+	rest, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(rest) > 0 {
+		return usagef("argumento inesperado: %s", rest[0])
+	}
+
+	migrator, ok := c.storage.(storage.Migrator)
+	if !ok {
+		return fmt.Errorf("o storage atual não suporta migrações")
+	}
+
+	report, err := migrator.Migrate(*dryRun)
+	if err != nil {
+		return err
+	}
+
+	if len(report.Steps) == 0 {
+		fmt.Printf("✅ Dados já estão no formato atual (v%d)\n", report.To)
+		return nil
+	}
+
+	fmt.Printf("📦 Migração de v%d para v%d:\n", report.From, report.To)
+	for _, step := range report.Steps {
+		fmt.Printf("   • v%d: %s\n", step.Version, step.Description)
+		for _, change := range step.Changes {
+			fmt.Printf("       - %s\n", change)
+		}
+	}
+
+	if *dryRun {
+		fmt.Println("🔎 Simulação: nada foi gravado")
+		return nil
+	}
+	if report.Backup != "" {
+		fmt.Printf("🛟 Cópia dos dados originais: %s\n", report.Backup)
+	}
+	fmt.Printf("✅ Dados migrados para v%d\n", report.To)
+	return nil
+}
+
+// parseDueFlag interpreta o valor de uma flag de prazo; vazio significa sem prazo
+func parseDueFlag(input string) (*time.Time, bool, error) {
+	if strings.TrimSpace(input) == "" {
+		return nil, false, nil
+	}
+
+	due, hasTime, err := task.ParseDue(input, time.Now())
+	if err != nil {
+		return nil, false, usagef("%v", err)
+	}
+	return &due, hasTime, nil
+}
+
+// parseRecurrenceFlag interpreta o valor de uma flag de repetição; vazio
+// significa sem repetição
+func parseRecurrenceFlag(rule string) (*task.Recurrence, error) {
+	if strings.TrimSpace(rule) == "" {
+		return nil, nil
+	}
+
+	recurrence, err := task.ParseRecurrence(rule)
+	if err != nil {
+		return nil, usagef("%v", err)
+	}
+	return recurrence, nil
+}
+
+// statusLabel retorna o nome do status de conclusão
+func statusLabel(completed bool) string {
+	if completed {
+		return "concluída"
+	}
+	return "pendente"
+}
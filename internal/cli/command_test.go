@@ -0,0 +1,49 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/lucianoZgabriel/go-cli-todo/internal/task"
+)
+
+// run executa um subcomando sobre a lista da CLI, sem carregar nem salvar
+func run(t *testing.T, c *CLI, args ...string) error {
+	t.Helper()
+	cmd, ok := findCommand(args[0])
+	if !ok {
+		t.Fatalf("comando desconhecido: %s", args[0])
+	}
+	return cmd.run(c, newFlagSet(cmd), args[1:])
+}
+
+// Subtarefas herdam o projeto da principal: pedir outro projeto é um erro
+// de uso, em vez de ser ignorado em silêncio
+func TestAddParentWithProject(t *testing.T) {
+	c := NewCLI(nil)
+	if _, err := c.todoList.AddProject("trabalho"); err != nil {
+		t.Fatal(err)
+	}
+	if err := run(t, c, "add", "--project", "trabalho", "release"); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, args := range [][]string{
+		{"add", "--parent", "1", "--project", "trabalho", "changelog"},
+		{"add", "--project", task.DefaultProjectName, "changelog", "--parent", "1"},
+	} {
+		err := run(t, c, args...)
+		if exitCode(err) != ExitUsage || err.Error() != "--project não pode ser usado com --parent" {
+			t.Errorf("%q: erro %v", args, err)
+		}
+	}
+	if len(c.todoList.Tasks) != 1 {
+		t.Fatalf("tarefas criadas apesar do erro: %v", c.todoList.Tasks)
+	}
+
+	if err := run(t, c, "add", "--parent", "1", "changelog"); err != nil {
+		t.Fatal(err)
+	}
+	if child := c.todoList.Tasks[1]; child.ParentID != 1 || child.ProjectID != c.todoList.Tasks[0].ProjectID {
+		t.Errorf("subtarefa: parent %d, projeto %d", child.ParentID, child.ProjectID)
+	}
+}
@@ -0,0 +1,85 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/lucianoZgabriel/go-cli-todo/internal/storage"
+	"github.com/lucianoZgabriel/go-cli-todo/internal/task"
+)
+
+// maxSaveAttempts limita as tentativas de salvar quando outro processo
+// continua gravando o arquivo entre uma tentativa e outra
+const maxSaveAttempts = 3
+
+// saveData salva dados no storage. Se outro processo alterou os dados
+// desde o carregamento, o conflito é resolvido antes de tentar de novo:
+// no menu o usuário escolhe entre mesclar, recarregar ou sobrescrever; nos
+// subcomandos as alterações são mescladas automaticamente.
+func (c *CLI) saveData() error {
+	for attempt := 1; ; attempt++ {
+		err := c.storage.Save(c.todoList)
+
+		var conflict *storage.ConflictError
+		if err == nil {
+			return c.saveHistory()
+		}
+		if !errors.As(err, &conflict) || attempt == maxSaveAttempts {
+			return err
+		}
+
+		saved, err := c.resolveConflict(conflict)
+		if err != nil || saved {
+			return err
+		}
+	}
+}
+
+// resolveConflict aplica a estratégia escolhida para um conflito. Retorna
+// saved=true quando não é preciso gravar de novo (dados recarregados).
+func (c *CLI) resolveConflict(conflict *storage.ConflictError) (saved bool, err error) {
+	fmt.Fprintln(os.Stderr, "⚠️  As tarefas foram alteradas por outro processo desde que foram carregadas.")
+
+	choice := "m"
+	if c.interactive {
+		fmt.Println("m. 🔀 Mesclar as alterações (em caso de conflito, as suas prevalecem)")
+		fmt.Println("r. 🔄 Recarregar e descartar as suas alterações")
+		fmt.Println("s. 💾 Sobrescrever com a sua versão")
+		choice = strings.ToLower(c.readInput("Escolha uma opção [m]: "))
+	}
+
+	switch choice {
+	case "", "m":
+		merged, conflicts := task.Merge(conflict.Base, c.todoList, conflict.Theirs)
+		c.todoList = merged
+		if len(conflicts) > 0 {
+			ids := make([]string, len(conflicts))
+			for i, id := range conflicts {
+				ids[i] = strconv.Itoa(id)
+			}
+			fmt.Fprintf(os.Stderr, "⚠️  Tarefas alteradas nos dois lados (mantida a sua versão): [%s]\n",
+				strings.Join(ids, ", "))
+		}
+		fmt.Println("🔀 Alterações mescladas")
+		// As operações registradas não se aplicam à lista mesclada, que
+		// pode ter tarefas renumeradas
+		if c.resetHistory() {
+			fmt.Println("ℹ️  O histórico de desfazer foi reiniciado")
+		}
+		return false, nil
+	case "r":
+		c.todoList = conflict.Theirs
+		// O histórico gravado acompanha a versão recarregada
+		c.resetHistory()
+		c.loadHistory()
+		fmt.Println("🔄 Dados recarregados; suas alterações foram descartadas")
+		return true, nil
+	case "s":
+		return false, nil
+	default:
+		return false, fmt.Errorf("opção inválida: %s; nada foi salvo", choice)
+	}
+}
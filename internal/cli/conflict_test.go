@@ -0,0 +1,80 @@
+package cli
+
+import (
+	"bufio"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/lucianoZgabriel/go-cli-todo/internal/storage"
+	"github.com/lucianoZgabriel/go-cli-todo/internal/task"
+)
+
+// conflicted prepara uma CLI com uma tarefa criada e registrada no
+// histórico, enquanto outro processo grava o mesmo arquivo com outra
+// tarefa e o seu próprio histórico
+func conflicted(t *testing.T) *CLI {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "tasks.json")
+
+	c := NewCLI(storage.NewJSONStorage(path))
+	if err := c.loadData(); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.record(func() error { c.todoList.AddTask("nossa", ""); return nil }); err != nil {
+		t.Fatal(err)
+	}
+
+	other := storage.NewJSONStorage(path)
+	theirs, err := other.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	history := task.NewHistory(task.DefaultHistoryDepth)
+	if err := history.Record(theirs, "", func() error { theirs.AddTask("deles", ""); return nil }); err != nil {
+		t.Fatal(err)
+	}
+	if err := other.Save(theirs); err != nil {
+		t.Fatal(err)
+	}
+	if err := other.(storage.HistoryStore).SaveHistory(history); err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+// Após mesclar, desfazer não pode aplicar operações registradas sobre a
+// versão anterior da lista
+func TestConflictMergeResetsHistory(t *testing.T) {
+	c := conflicted(t)
+	if err := c.saveData(); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(c.todoList.Tasks) != 2 {
+		t.Errorf("lista mesclada com %d tarefas, esperado 2", len(c.todoList.Tasks))
+	}
+	if len(c.history.Undo) > 0 {
+		t.Errorf("histórico mantido após a mesclagem: %v", c.history.Undo)
+	}
+}
+
+// Ao recarregar, o histórico passa a ser o gravado com a versão recarregada
+func TestConflictReloadLoadsStoredHistory(t *testing.T) {
+	c := conflicted(t)
+	c.interactive = true
+	c.scanner = bufio.NewScanner(strings.NewReader("r\n"))
+	if err := c.saveData(); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(c.todoList.Tasks) != 1 || c.todoList.Tasks[0].Title != "deles" {
+		t.Fatalf("lista recarregada: %v", c.todoList.Tasks)
+	}
+	if len(c.history.Undo) != 1 || c.history.Undo[0].Label != "criar [1] deles" {
+		t.Fatalf("histórico após recarregar: %v", c.history.Undo)
+	}
+	if err := c.undo(); err != nil || len(c.todoList.Tasks) != 0 {
+		t.Errorf("desfazer após recarregar: %v, tarefas %v", err, c.todoList.Tasks)
+	}
+}
@@ -0,0 +1,240 @@
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/lucianoZgabriel/go-cli-todo/internal/task"
+)
+
+// defaultEditor é usado quando nem $VISUAL nem $EDITOR estão definidos
+const defaultEditor = "vi"
+
+// frontMatterDelimiter separa os campos da descrição no arquivo editado
+const frontMatterDelimiter = "---"
+
+// editInEditor abre a tarefa no editor do usuário ($VISUAL ou $EDITOR) em
+// um arquivo temporário: os campos ficam no cabeçalho (front-matter) e a
+// descrição, que pode ter várias linhas, no corpo em Markdown. Se o
+// arquivo salvo for inválido, o erro é exibido e o usuário pode reabrir o
+// editor com o conteúdo que digitou.
+func (c *CLI) editInEditor(id int) error {
+	t, err := c.todoList.GetTask(id)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.CreateTemp("", fmt.Sprintf("todo-%d-*.md", id))
+	if err != nil {
+		return fmt.Errorf("erro ao criar arquivo temporário: %w", err)
+	}
+	path := file.Name()
+	defer os.Remove(path)
+
+	_, err = file.Write(c.renderTaskFile(t))
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("erro ao criar arquivo temporário: %w", err)
+	}
+
+	for {
+		if err := c.runEditor(path); err != nil {
+			return err
+		}
+
+		err := c.applyTaskFile(t, path)
+		if err == nil {
+			return nil
+		}
+
+		// Sem um terminal para perguntar, o erro vai para o código de saída
+		if !isTerminal(os.Stdin) {
+			return fmt.Errorf("arquivo inválido: %w", err)
+		}
+		fmt.Printf("❌ Arquivo inválido: %v\n", err)
+		answer, ok := c.tryReadInput("🔁 Reabrir o editor para corrigir? (s/n): ")
+		if !ok || strings.ToLower(answer) != "s" {
+			return fmt.Errorf("edição cancelada: %w", err)
+		}
+	}
+}
+
+// applyTaskFile lê o arquivo editado e aplica à tarefa os campos alterados
+func (c *CLI) applyTaskFile(t *task.Task, path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	update, err := c.parseTaskFile(t, data)
+	if err != nil {
+		return err
+	}
+	if update.IsEmpty() {
+		fmt.Println("ℹ️  Nenhum campo alterado.")
+		return nil
+	}
+
+	changes, err := c.todoList.UpdateTask(t.ID, update)
+	if err != nil {
+		return err
+	}
+	reportChanges(t.ID, changes)
+	return nil
+}
+
+// runEditor executa o editor configurado sobre o arquivo e espera ele fechar
+func (c *CLI) runEditor(path string) error {
+	editor, args := editorCommand()
+	cmd := exec.Command(args[0], append(args[1:], path)...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	err := cmd.Run()
+	c.discardInterrupts()
+	if err != nil {
+		return fmt.Errorf("erro ao executar o editor '%s': %w", editor, err)
+	}
+	return nil
+}
+
+// editorCommand retorna o editor configurado em $VISUAL ou $EDITOR e o
+// comando separado em argumentos, como em EDITOR="code --wait". Variáveis
+// vazias ou só com espaços são ignoradas.
+func editorCommand() (string, []string) {
+	for _, editor := range []string{os.Getenv("VISUAL"), os.Getenv("EDITOR")} {
+		if args := strings.Fields(editor); len(args) > 0 {
+			return editor, args
+		}
+	}
+	return defaultEditor, []string{defaultEditor}
+}
+
+// discardInterrupts descarta os Ctrl-C recebidos enquanto o editor estava
+// aberto: eles eram para o editor, não para encerrar a CLI
+func (c *CLI) discardInterrupts() {
+	select {
+	case sig := <-c.signals:
+		if sig != os.Interrupt {
+			c.signals <- sig
+		}
+	default:
+	}
+}
+
+// renderTaskFile serializa a tarefa no formato editado pelo usuário
+func (c *CLI) renderTaskFile(t *task.Task) []byte {
+	var buf bytes.Buffer
+	fmt.Fprintln(&buf, frontMatterDelimiter)
+	fmt.Fprintf(&buf, "title: %s\n", t.Title)
+	fmt.Fprintf(&buf, "priority: %s\n", t.Priority.String())
+	fmt.Fprintf(&buf, "due: %s\n", t.FormatDue())
+	fmt.Fprintf(&buf, "every: %s\n", recurrenceRule(t.Recurrence))
+	fmt.Fprintf(&buf, "tags: %s\n", strings.Join(t.Tags, ", "))
+	if !t.IsSubtask() {
+		fmt.Fprintf(&buf, "project: %s\n", c.todoList.ProjectName(t.ProjectID))
+	}
+	fmt.Fprintln(&buf, "# Campos vazios removem o valor. Linhas com # são ignoradas.")
+	fmt.Fprintln(&buf, "# A descrição vai abaixo da linha ---, em Markdown.")
+	fmt.Fprintln(&buf, frontMatterDelimiter)
+	buf.WriteString(t.Description)
+	if t.Description != "" && !strings.HasSuffix(t.Description, "\n") {
+		buf.WriteByte('\n')
+	}
+	return buf.Bytes()
+}
+
+// parseTaskFile interpreta o arquivo editado e monta a edição com os campos
+// que diferem da tarefa atual. Erros de formato indicam a linha.
+func (c *CLI) parseTaskFile(t *task.Task, data []byte) (task.TaskUpdate, error) {
+	var update task.TaskUpdate
+
+	lines := strings.Split(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n")
+	if len(lines) == 0 || strings.TrimSpace(lines[0]) != frontMatterDelimiter {
+		return update, fmt.Errorf("linha 1: o arquivo deve começar com '%s'", frontMatterDelimiter)
+	}
+
+	fields := make(map[string]string)
+	end := -1
+	for i := 1; i < len(lines); i++ {
+		line := strings.TrimSpace(lines[i])
+		if line == frontMatterDelimiter {
+			end = i
+			break
+		}
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			return update, fmt.Errorf("linha %d: esperado 'campo: valor', encontrado '%s'", i+1, line)
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+		if _, known := fileFields[key]; !known {
+			return update, fmt.Errorf("linha %d: campo desconhecido '%s'", i+1, key)
+		}
+		if _, repeated := fields[key]; repeated {
+			return update, fmt.Errorf("linha %d: campo '%s' repetido", i+1, key)
+		}
+		fields[key] = strings.TrimSpace(value)
+	}
+	if end < 0 {
+		return update, fmt.Errorf("cabeçalho sem a linha '%s' de fechamento", frontMatterDelimiter)
+	}
+
+	// o corpo é a descrição, sem as linhas em branco das pontas
+	description := strings.Trim(strings.Join(lines[end+1:], "\n"), "\n")
+	if description != t.Description {
+		update.Description = &description
+	}
+
+	changed := func(key, current string) *string {
+		value, ok := fields[key]
+		if !ok || value == current {
+			return nil
+		}
+		return &value
+	}
+
+	update.Title = changed("title", t.Title)
+	if value := changed("priority", t.Priority.String()); value != nil {
+		priority, err := task.ParsePriority(*value)
+		if err != nil {
+			return update, err
+		}
+		update.Priority = &priority
+	}
+	update.Due = changed("due", t.FormatDue())
+	update.Recurrence = changed("every", recurrenceRule(t.Recurrence))
+	if value := changed("tags", strings.Join(t.Tags, ", ")); value != nil {
+		var tags []string
+		if *value != "" {
+			var err error
+			if tags, err = parseTagList(*value); err != nil {
+				return update, fmt.Errorf("tags: %w", err)
+			}
+		}
+		update.Tags = &tags
+	}
+	if !t.IsSubtask() {
+		update.Project = changed("project", c.todoList.ProjectName(t.ProjectID))
+	}
+	return update, nil
+}
+
+// fileFields lista os campos aceitos no cabeçalho do arquivo editado
+var fileFields = map[string]struct{}{
+	"title":    {},
+	"priority": {},
+	"due":      {},
+	"every":    {},
+	"tags":     {},
+	"project":  {},
+}
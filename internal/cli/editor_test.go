@@ -0,0 +1,116 @@
+package cli
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lucianoZgabriel/go-cli-todo/internal/task"
+)
+
+func TestEditorCommand(t *testing.T) {
+	for _, tt := range []struct {
+		visual, editor string
+		want           []string
+	}{
+		{"", "", []string{"vi"}},
+		{"   ", "", []string{"vi"}},
+		{"", "\t", []string{"vi"}},
+		{" ", "nano", []string{"nano"}},
+		{"", "code --wait", []string{"code", "--wait"}},
+		{"hx", "nano", []string{"hx"}},
+	} {
+		t.Setenv("VISUAL", tt.visual)
+		t.Setenv("EDITOR", tt.editor)
+		if _, args := editorCommand(); !reflect.DeepEqual(args, tt.want) {
+			t.Errorf("VISUAL=%q EDITOR=%q: %q, esperado %q", tt.visual, tt.editor, args, tt.want)
+		}
+	}
+}
+
+// editable cria uma CLI com uma tarefa que usa todos os campos do arquivo
+func editable(t *testing.T) (*CLI, *task.Task) {
+	t.Helper()
+	c := NewCLI(nil)
+	project, err := c.todoList.AddProject("trabalho")
+	if err != nil {
+		t.Fatal(err)
+	}
+	deploy := c.todoList.AddTask("Deploy da API #infra #urgente", "Passos:\n\n1. build\n2. subir")
+	deploy.Priority = task.PriorityHigh
+	deploy.ProjectID = project.ID
+	due := time.Date(2026, time.November, 3, 22, 0, 0, 0, time.Local)
+	deploy.DueDate, deploy.DueHasTime = &due, true
+	if deploy.Recurrence, err = task.ParseRecurrence("weekly:mon,fri"); err != nil {
+		t.Fatal(err)
+	}
+	return c, deploy
+}
+
+// Um arquivo salvo sem alterações não muda nenhum campo
+func TestTaskFileRoundTrip(t *testing.T) {
+	c, deploy := editable(t)
+	update, err := c.parseTaskFile(deploy, c.renderTaskFile(deploy))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !update.IsEmpty() {
+		t.Errorf("arquivo sem alterações gerou a edição %+v", update)
+	}
+
+	// Fins de linha do Windows também não são alterações
+	crlf := strings.ReplaceAll(string(c.renderTaskFile(deploy)), "\n", "\r\n")
+	if update, err := c.parseTaskFile(deploy, []byte(crlf)); err != nil || !update.IsEmpty() {
+		t.Errorf("arquivo com CRLF: edição %+v, erro %v", update, err)
+	}
+}
+
+func TestParseTaskFileChanges(t *testing.T) {
+	c, deploy := editable(t)
+	file := strings.NewReplacer(
+		"title: Deploy da API", "Title:   Deploy do front  ",
+		"priority: high", "priority: baixa",
+		"tags: infra, urgente", "tags:",
+		"1. build", "1. testar\n2. build",
+	).Replace(string(c.renderTaskFile(deploy)))
+
+	update, err := c.parseTaskFile(deploy, []byte(file))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if update.Title == nil || *update.Title != "Deploy do front" {
+		t.Errorf("título: %v", update.Title)
+	}
+	if update.Priority == nil || *update.Priority != task.PriorityLow {
+		t.Errorf("prioridade: %v", update.Priority)
+	}
+	if update.Tags == nil || len(*update.Tags) != 0 {
+		t.Errorf("tags: %v, esperado a remoção de todas", update.Tags)
+	}
+	if want := "Passos:\n\n1. testar\n2. build\n2. subir"; update.Description == nil || *update.Description != want {
+		t.Errorf("descrição: %q", *update.Description)
+	}
+	if update.Due != nil || update.Recurrence != nil || update.Project != nil {
+		t.Errorf("campos não editados alterados: %+v", update)
+	}
+}
+
+func TestParseTaskFileErrors(t *testing.T) {
+	c, deploy := editable(t)
+	for file, want := range map[string]string{
+		"":                                     "linha 1: o arquivo deve começar com '---'",
+		"title: x\n---\n":                      "linha 1: o arquivo deve começar com '---'",
+		"---\ntitle: x\n":                      "cabeçalho sem a linha '---' de fechamento",
+		"---\ntitle: x\ndescrição\n---\n":      "linha 3: esperado 'campo: valor', encontrado 'descrição'",
+		"---\ntitle: x\nstatus: done\n---":     "linha 3: campo desconhecido 'status'",
+		"---\ntitle: x\n# nota\nTITLE: y\n---": "linha 4: campo 'title' repetido",
+		"---\npriority: urgentíssima\n---":     "prioridade inválida",
+		"---\ntags: infra, a+b\n---":           "tags: tag inválida: a+b",
+	} {
+		_, err := c.parseTaskFile(deploy, []byte(file))
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("%q: erro %v, esperado %q", file, err, want)
+		}
+	}
+}
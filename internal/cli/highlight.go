@@ -0,0 +1,63 @@
+package cli
+
+import (
+	"os"
+	"strings"
+
+	"github.com/lucianoZgabriel/go-cli-todo/internal/task"
+)
+
+// Sequências ANSI usadas para destacar os trechos encontrados na busca
+const (
+	highlightStart = "\033[1;33m" // negrito amarelo
+	highlightEnd   = "\033[0m"
+)
+
+// colorEnabled informa se a saída padrão aceita cores: um terminal, sem
+// NO_COLOR definido e com TERM diferente de "dumb"
+func colorEnabled() bool {
+	if _, ok := os.LookupEnv("NO_COLOR"); ok || os.Getenv("TERM") == "dumb" {
+		return false
+	}
+	return isTerminal(os.Stdout)
+}
+
+// highlighted retorna uma cópia da tarefa do resultado com as letras
+// encontradas destacadas no título, se houver cores
+func (c *CLI) highlighted(result task.Result) task.Task {
+	t := result.Task
+	if c.color {
+		t.Title = highlight(t.Title, result.Highlights)
+	}
+	return t
+}
+
+// highlight envolve as runas nas posições informadas (em ordem crescente)
+// com as sequências de destaque, agrupando as vizinhas
+func highlight(text string, positions []int) string {
+	if len(positions) == 0 {
+		return text
+	}
+	marked := make(map[int]bool, len(positions))
+	for _, pos := range positions {
+		marked[pos] = true
+	}
+
+	var b strings.Builder
+	open := false
+	for i, r := range []rune(text) {
+		if marked[i] != open {
+			open = marked[i]
+			if open {
+				b.WriteString(highlightStart)
+			} else {
+				b.WriteString(highlightEnd)
+			}
+		}
+		b.WriteRune(r)
+	}
+	if open {
+		b.WriteString(highlightEnd)
+	}
+	return b.String()
+}
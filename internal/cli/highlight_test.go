@@ -0,0 +1,61 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lucianoZgabriel/go-cli-todo/internal/task"
+)
+
+// marks troca as sequências ANSI por colchetes, para facilitar a leitura
+var marks = strings.NewReplacer(highlightStart, "[", highlightEnd, "]")
+
+func TestHighlight(t *testing.T) {
+	for _, tt := range []struct {
+		text      string
+		positions []int
+		want      string
+	}{
+		{"Deploy", nil, "Deploy"},
+		{"Deploy", []int{0, 1, 2}, "[Dep]loy"},
+		{"Deploy", []int{0, 2, 5}, "[D]e[p]lo[y]"},
+		{"Deploy", []int{1, 1, 2, 2}, "D[ep]loy"},
+		{"Deploy", []int{9}, "Deploy"},
+		// Posições são runas, não bytes
+		{"Ação rápida", []int{0, 1, 2, 3}, "[Ação] rápida"},
+		{"Ação rápida", []int{6, 7}, "Ação r[áp]ida"},
+		{"日本語のテスト", []int{1, 2, 6}, "日[本語]のテス[ト]"},
+		{"deploy 🚀 já", []int{7, 9, 10}, "deploy [🚀] [já]"},
+	} {
+		if got := marks.Replace(highlight(tt.text, tt.positions)); got != tt.want {
+			t.Errorf("highlight(%q, %v) = %q, esperado %q", tt.text, tt.positions, got, tt.want)
+		}
+	}
+}
+
+// As posições da busca, feita sem acentos, destacam as letras originais
+func TestHighlightedResult(t *testing.T) {
+	query, err := task.ParseQuery("acao dply", time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	tl := task.NewTodoList()
+	tl.AddTask("Ação de deploy", "")
+	results := query.Rank(tl, tl.Tasks, time.Now())
+	if len(results) != 1 {
+		t.Fatalf("resultados: %v", results)
+	}
+
+	c := NewCLI(nil)
+	if title := c.highlighted(results[0]).Title; title != "Ação de deploy" {
+		t.Errorf("sem cores: %q", title)
+	}
+	c.color = true
+	if title := marks.Replace(c.highlighted(results[0]).Title); title != "[Ação] de [d]e[pl]o[y]" {
+		t.Errorf("com cores: %q", title)
+	}
+	if results[0].Task.Title != "Ação de deploy" {
+		t.Errorf("o destaque alterou a tarefa do resultado: %q", results[0].Task.Title)
+	}
+}
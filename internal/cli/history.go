@@ -0,0 +1,83 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/lucianoZgabriel/go-cli-todo/internal/storage"
+	"github.com/lucianoZgabriel/go-cli-todo/internal/task"
+)
+
+// record executa uma ação que altera a lista registrando-a no histórico,
+// para que possa ser desfeita, e no histórico de auditoria das tarefas
+func (c *CLI) record(action func() error) error {
+	return c.history.Record(c.todoList, "", func() error {
+		return c.todoList.Track(action)
+	})
+}
+
+// undo desfaz a última operação
+func (c *CLI) undo() error {
+	var op *task.Operation
+	err := c.todoList.Track(func() (err error) {
+		op, err = c.history.UndoLast(c.todoList)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Printf("↩️  Desfeito: %s\n", op.Label)
+	return nil
+}
+
+// redo refaz a última operação desfeita
+func (c *CLI) redo() error {
+	var op *task.Operation
+	err := c.todoList.Track(func() (err error) {
+		op, err = c.history.RedoLast(c.todoList)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Printf("↪️  Refeito: %s\n", op.Label)
+	return nil
+}
+
+// loadHistory carrega o histórico persistido, se o storage o suportar. Um
+// histórico ilegível é descartado: ele não deve impedir o uso da lista.
+func (c *CLI) loadHistory() {
+	store, ok := c.storage.(storage.HistoryStore)
+	if !ok || c.history.Depth <= 0 {
+		return
+	}
+
+	history, err := store.LoadHistory()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "⚠️  Histórico de desfazer ignorado: %v\n", err)
+		return
+	}
+	history.SetDepth(c.history.Depth)
+	c.history = history
+}
+
+// resetHistory descarta as operações que podiam ser desfeitas e refeitas,
+// mantendo a profundidade. Retorna se havia alguma.
+func (c *CLI) resetHistory() bool {
+	discarded := len(c.history.Undo) > 0 || len(c.history.Redo) > 0
+	c.history = task.NewHistory(c.history.Depth)
+	return discarded
+}
+
+// saveHistory persiste o histórico, se o storage o suportar
+func (c *CLI) saveHistory() error {
+	store, ok := c.storage.(storage.HistoryStore)
+	if !ok || c.history.Depth <= 0 {
+		return nil
+	}
+
+	if err := store.SaveHistory(c.history); err != nil {
+		return fmt.Errorf("erro ao salvar histórico: %w", err)
+	}
+	return nil
+}
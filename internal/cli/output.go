@@ -0,0 +1,73 @@
+package cli
+
+import (
+	"flag"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/lucianoZgabriel/go-cli-todo/internal/output"
+	"github.com/lucianoZgabriel/go-cli-todo/internal/task"
+)
+
+// outputFlag registra a flag --output (e o atalho -o) de um subcomando
+func outputFlag(fs *flag.FlagSet) *string {
+	name := new(string)
+	usage := "formato da saída: " + strings.Join(output.Names(), ", ")
+	fs.StringVar(name, "output", string(output.Text), usage)
+	fs.StringVar(name, "o", string(output.Text), "atalho para --output")
+	return name
+}
+
+// parseOutput valida o formato pedido em --output
+func parseOutput(name string) (output.Format, error) {
+	format, err := output.ParseFormat(name)
+	if err != nil {
+		return "", usagef("%v", err)
+	}
+	return format, nil
+}
+
+// writeTasks escreve as tarefas em um formato legível por máquina
+func (c *CLI) writeTasks(format output.Format, tasks []task.Task) error {
+	records := make([]output.Record, len(tasks))
+	for i, t := range tasks {
+		records[i] = c.taskRecord(t)
+	}
+	return output.WriteList(os.Stdout, format, output.TaskColumns, records)
+}
+
+// writeResults escreve os resultados de uma busca, com a pontuação
+func (c *CLI) writeResults(format output.Format, results []task.Result) error {
+	records := make([]output.Record, len(results))
+	for i, result := range results {
+		records[i] = output.SearchRecord{TaskRecord: c.taskRecord(result.Task), Score: result.Score}
+	}
+	return output.WriteList(os.Stdout, format, output.SearchColumns, records)
+}
+
+// taskRecord converte uma tarefa para a saída, com o nome do seu projeto
+func (c *CLI) taskRecord(t task.Task) output.TaskRecord {
+	return output.NewTaskRecord(t, c.todoList.ProjectName(t.ProjectID))
+}
+
+// statsRecord resume as tarefas do escopo atual
+func (c *CLI) statsRecord() output.StatsRecord {
+	scope := c.scope()
+	total, completed, pending := scope.Stats()
+	now := time.Now()
+
+	record := output.StatsRecord{
+		Total:       total,
+		Completed:   completed,
+		Pending:     pending,
+		Overdue:     len(scope.OverdueTasks(now)),
+		DueToday:    len(scope.DueTodayTasks(now)),
+		DueThisWeek: len(scope.DueThisWeekTasks(now)),
+	}
+	if c.project != allProjects {
+		name := c.todoList.ProjectName(c.project)
+		record.Project = &name
+	}
+	return record
+}
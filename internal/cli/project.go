@@ -0,0 +1,154 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/lucianoZgabriel/go-cli-todo/internal/task"
+)
+
+// manageProjects exibe o submenu de projetos
+func (c *CLI) manageProjects() error {
+	fmt.Println("\n=== 📁 PROJETOS ===")
+	c.displayProjects(false)
+
+	fmt.Println()
+	fmt.Println("1. 🔀 Trocar projeto ativo")
+	fmt.Println("2. ➕ Criar projeto")
+	fmt.Println("3. ✏️  Renomear projeto")
+	fmt.Println("4. 📦 Arquivar/desarquivar projeto")
+	fmt.Println("5. 🚚 Mover tarefa para outro projeto")
+	fmt.Println()
+
+	switch choice := c.readInput("Escolha uma opção (Enter para voltar): "); choice {
+	case "":
+		return nil
+	case "1":
+		return c.switchProject()
+	case "2":
+		return c.createProject()
+	case "3":
+		return c.renameProject()
+	case "4":
+		return c.toggleProjectArchived()
+	case "5":
+		return c.moveTaskToProject()
+	default:
+		return fmt.Errorf("opção inválida: %s", choice)
+	}
+}
+
+// displayProjects lista os projetos com suas estatísticas
+func (c *CLI) displayProjects(includeArchived bool) {
+	for _, project := range c.todoList.ListProjects(includeArchived) {
+		total, completed, pending := c.todoList.ProjectView(project.ID).Stats()
+
+		marker := "  "
+		if project.ID == c.project {
+			marker = "👉"
+		}
+		archived := ""
+		if project.Archived {
+			archived = " 📦 arquivado"
+		}
+
+		fmt.Printf("%s 📁 %s — %d total | ✅ %d | ⏳ %d%s\n",
+			marker, project.Name, total, completed, pending, archived)
+	}
+}
+
+// switchProject troca o projeto ativo
+func (c *CLI) switchProject() error {
+	name := c.readInput("📁 Nome do projeto (Enter para todos): ")
+	if err := c.useProject(name); err != nil {
+		return err
+	}
+
+	fmt.Printf("🔀 Projeto ativo: %s\n", c.projectLabel())
+	return nil
+}
+
+// createProject cria um novo projeto
+func (c *CLI) createProject() error {
+	project, err := c.todoList.AddProject(c.readInput("📁 Nome do novo projeto: "))
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("✅ Projeto '%s' criado!\n", project.Name)
+	return nil
+}
+
+// renameProject renomeia um projeto existente
+func (c *CLI) renameProject() error {
+	name := c.readInput("📁 Projeto a renomear: ")
+	newName := c.readInput("✏️  Novo nome: ")
+
+	if err := c.todoList.RenameProject(name, newName); err != nil {
+		return err
+	}
+
+	fmt.Printf("✏️  Projeto '%s' renomeado para '%s'!\n", name, newName)
+	return nil
+}
+
+// toggleProjectArchived arquiva um projeto ativo ou desarquiva um arquivado
+func (c *CLI) toggleProjectArchived() error {
+	fmt.Println("📦 Projetos (incluindo arquivados):")
+	c.displayProjects(true)
+
+	project, err := c.todoList.FindProject(c.readInput("📁 Projeto: "))
+	if err != nil {
+		return err
+	}
+
+	if err := c.todoList.SetProjectArchived(project.Name, !project.Archived); err != nil {
+		return err
+	}
+
+	if project.Archived {
+		fmt.Printf("📂 Projeto '%s' desarquivado!\n", project.Name)
+		return nil
+	}
+
+	// O projeto arquivado deixa de ser o ativo
+	if c.project == project.ID {
+		c.project = allProjects
+	}
+	fmt.Printf("📦 Projeto '%s' arquivado!\n", project.Name)
+	return nil
+}
+
+// moveTaskToProject move uma tarefa para outro projeto
+func (c *CLI) moveTaskToProject() error {
+	scope := c.scope()
+	if len(scope.Tasks) == 0 {
+		fmt.Println("📭 Nenhuma tarefa encontrada!")
+		return nil
+	}
+
+	fmt.Println("📋 Tarefas disponíveis:")
+	for _, t := range scope.Tasks {
+		c.displayTaskSummary(&t)
+	}
+	fmt.Println()
+
+	id, err := c.readInt("🆔 Digite o ID da tarefa: ")
+	if err != nil {
+		return fmt.Errorf("ID inválido: %w", err)
+	}
+
+	name := c.readInput("📁 Projeto de destino: ")
+	if err := c.todoList.MoveTask(id, name); err != nil {
+		return err
+	}
+
+	t, _ := c.todoList.GetTask(id)
+	fmt.Printf("🚚 Tarefa [%d] movida para '%s'!\n", t.ID, c.todoList.ProjectName(t.ProjectID))
+	return nil
+}
+
+// projectStatsLine formata as estatísticas de um projeto em uma linha
+func projectStatsLine(ps task.ProjectStat) string {
+	return fmt.Sprintf("📁 %s — %d total | ✅ %d | ⏳ %d",
+		ps.Project.Name, ps.Total, ps.Completed, ps.Pending)
+}
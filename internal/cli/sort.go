@@ -0,0 +1,69 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/lucianoZgabriel/go-cli-todo/internal/task"
+)
+
+// sortFlag registra a flag --sort de um subcomando
+func sortFlag(fs *flag.FlagSet) *string {
+	return fs.String("sort", "", "ordenação, ex.: priority:desc,due:asc (critérios: priority, due, created, updated, title, status, rank, id)")
+}
+
+// parseSort valida a ordenação pedida em --sort; vazia retorna nil
+func parseSort(input string) (task.SortSpec, error) {
+	spec, err := task.ParseSortSpec(input)
+	if err != nil {
+		return nil, usagef("%v", err)
+	}
+	return spec, nil
+}
+
+// listingSort retorna a ordenação pedida em --sort ou, sem ela, a padrão
+// salva para a listagem (veja "todo sort")
+func (c *CLI) listingSort(listing, input string) (task.SortSpec, error) {
+	if strings.TrimSpace(input) == "" {
+		return c.todoList.ListingSort(listing), nil
+	}
+	return parseSort(input)
+}
+
+// readSort pergunta a ordenação de uma listagem no menu. Enter mantém a
+// ordenação padrão salva para a listagem ou, sem ela, a ordem descrita em
+// fallback. Uma ordenação diferente pode ser salva como a nova padrão.
+func (c *CLI) readSort(listing, fallback string) (task.SortSpec, error) {
+	saved := c.todoList.ListingSort(listing)
+	current := fallback
+	if saved != nil {
+		current = saved.String()
+	}
+
+	input := c.readInput(fmt.Sprintf("↕️  Ordenar por, ex.: due:asc,title (Enter para %s): ", current))
+	if input == "" {
+		return saved, nil
+	}
+	spec, err := task.ParseSortSpec(input)
+	if err != nil || spec.String() == saved.String() {
+		return spec, err
+	}
+
+	if strings.ToLower(c.readInput("💾 Salvar como ordenação padrão desta listagem? (s/n): ")) == "s" {
+		if err := c.todoList.SetListingSort(listing, input); err != nil {
+			return nil, err
+		}
+		fmt.Printf("↕️  Ordenação padrão salva: %s\n", spec)
+	}
+	return spec, nil
+}
+
+// displayListingSort mostra a ordenação padrão de uma listagem
+func (c *CLI) displayListingSort(listing string) {
+	spec := "padrão"
+	if saved := c.todoList.ListingSort(listing); saved != nil {
+		spec = saved.String()
+	}
+	fmt.Printf("↕️  %s: %s\n", listing, spec)
+}
@@ -0,0 +1,38 @@
+package cli
+
+import (
+	"bufio"
+	"strings"
+	"testing"
+)
+
+// No menu, uma ordenação diferente pode virar a padrão da listagem, e
+// Enter passa a usá-la
+func TestReadSortSavesListingDefault(t *testing.T) {
+	c := NewCLI(nil)
+	c.scanner = bufio.NewScanner(strings.NewReader("due\ns\n\ntitle\nn\n"))
+
+	for _, want := range []string{"due:asc", "due:asc", "title:asc"} {
+		spec, err := c.readSort("pending", "prioridade e posição")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if spec.String() != want {
+			t.Errorf("ordenação %q, esperado %q", spec, want)
+		}
+	}
+	if saved := c.todoList.ListingSorts; len(saved) != 1 || saved["pending"] != "due:asc" {
+		t.Errorf("ordenações salvas: %v", saved)
+	}
+
+	// --sort vence a ordenação salva só no comando em que aparece
+	for input, want := range map[string]string{"": "due:asc", "id": "id:asc"} {
+		spec, err := c.listingSort("pending", input)
+		if err != nil || spec.String() != want {
+			t.Errorf("--sort %q: %q, erro %v, esperado %q", input, spec, err, want)
+		}
+	}
+	if _, err := c.listingSort("pending", "tamanho"); exitCode(err) != ExitUsage {
+		t.Errorf("--sort inválido: erro %v", err)
+	}
+}
@@ -0,0 +1,128 @@
+package cli
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/lucianoZgabriel/go-cli-todo/internal/task"
+)
+
+// manageTrash exibe a lixeira e o submenu para restaurar ou apagar tarefas
+func (c *CLI) manageTrash() error {
+	fmt.Println("\n=== 🗑️ LIXEIRA ===")
+	if len(c.todoList.Trash) == 0 {
+		fmt.Println("📭 A lixeira está vazia!")
+		return nil
+	}
+	c.displayTrash()
+
+	fmt.Println()
+	fmt.Println("1. ♻️  Restaurar tarefa")
+	fmt.Println("2. ❌ Apagar tarefa definitivamente")
+	fmt.Println("3. 🧹 Esvaziar lixeira")
+	fmt.Println()
+
+	switch choice := c.readInput("Escolha uma opção (Enter para voltar): "); choice {
+	case "":
+		return nil
+	case "1":
+		return c.restoreFromTrash()
+	case "2":
+		return c.purgeFromTrash()
+	case "3":
+		return c.emptyTrash()
+	default:
+		return fmt.Errorf("opção inválida: %s", choice)
+	}
+}
+
+// displayTrash lista as tarefas da lixeira, das removidas mais recentemente
+// para as mais antigas
+func (c *CLI) displayTrash() {
+	now := time.Now()
+	for _, t := range c.todoList.TrashedTasks() {
+		fmt.Printf("  🗑️  [%d] %s — removida em %s%s\n",
+			t.ID, t.Title, t.DeletedAt.Format("02/01/2006 15:04"), c.purgeNotice(t, now))
+	}
+}
+
+// purgeNotice informa quando a tarefa será apagada automaticamente
+func (c *CLI) purgeNotice(t task.Task, now time.Time) string {
+	if c.trashRetentionDays <= 0 {
+		return ""
+	}
+
+	purgeAt := t.DeletedAt.AddDate(0, 0, c.trashRetentionDays)
+	days := int(math.Ceil(purgeAt.Sub(now).Hours() / 24))
+	if days < 1 {
+		return " (apagada em menos de um dia)"
+	}
+	return fmt.Sprintf(" (apagada em %d dias)", days)
+}
+
+// restoreFromTrash devolve uma tarefa da lixeira para a lista
+func (c *CLI) restoreFromTrash() error {
+	id, err := c.readInt("🆔 ID da tarefa para restaurar: ")
+	if err != nil {
+		return fmt.Errorf("ID inválido: %w", err)
+	}
+
+	restored, err := c.todoList.RestoreTask(id)
+	if err != nil {
+		return err
+	}
+	reportRestored(restored)
+	return nil
+}
+
+// purgeFromTrash apaga definitivamente uma tarefa da lixeira
+func (c *CLI) purgeFromTrash() error {
+	id, err := c.readInt("🆔 ID da tarefa para apagar definitivamente: ")
+	if err != nil {
+		return fmt.Errorf("ID inválido: %w", err)
+	}
+
+	if err := c.todoList.PurgeTask(id); err != nil {
+		return err
+	}
+	fmt.Printf("❌ Tarefa [%d] apagada definitivamente\n", id)
+	return nil
+}
+
+// emptyTrash apaga definitivamente todas as tarefas da lixeira
+func (c *CLI) emptyTrash() error {
+	fmt.Printf("\n⚠️  %d tarefa(s) serão apagadas definitivamente.\n", len(c.todoList.Trash))
+	if strings.ToLower(c.readInput("Digite 'sim' para confirmar: ")) != "sim" {
+		fmt.Println("❌ Operação cancelada.")
+		return nil
+	}
+
+	purged := c.todoList.PurgeTrash(time.Time{})
+	fmt.Printf("🧹 %d tarefa(s) apagada(s) definitivamente\n", purged)
+	return nil
+}
+
+// reportRestored informa as tarefas restauradas da lixeira
+func reportRestored(ids []int) {
+	fmt.Printf("♻️  Tarefa [%d] restaurada\n", ids[0])
+	if len(ids) > 1 {
+		fmt.Printf("🌳 %d subtarefa(s) restaurada(s) junto\n", len(ids)-1)
+	}
+}
+
+// autoPurgeTrash apaga as tarefas que estão na lixeira há mais tempo que
+// o prazo de retenção
+func (c *CLI) autoPurgeTrash() {
+	if c.trashRetentionDays <= 0 {
+		return
+	}
+
+	before := time.Now().AddDate(0, 0, -c.trashRetentionDays)
+	if purged := c.todoList.PurgeTrash(before); purged > 0 {
+		fmt.Fprintf(os.Stderr, "🧹 %d tarefa(s) apagada(s) da lixeira (removidas há mais de %d dias)\n",
+			purged, c.trashRetentionDays)
+	}
+}
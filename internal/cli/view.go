@@ -0,0 +1,193 @@
+package cli
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lucianoZgabriel/go-cli-todo/internal/task"
+)
+
+// viewActions são as ações do subcomando "view", que não podem ser usadas
+// como nome de visão
+var viewActions = map[string]bool{"list": true, "save": true, "rm": true, "pin": true, "unpin": true}
+
+// pinnedViewPrefix antecede o número de uma visão fixada no menu ("v1")
+const pinnedViewPrefix = "v"
+
+// manageViews exibe o submenu de visões salvas
+func (c *CLI) manageViews() error {
+	fmt.Println("\n=== 🔎 VISÕES SALVAS ===")
+	if len(c.todoList.Views) == 0 {
+		fmt.Println("📭 Nenhuma visão salva!")
+	} else {
+		c.displayViews()
+	}
+
+	fmt.Println()
+	fmt.Println("1. ▶️  Abrir visão")
+	fmt.Println("2. 💾 Salvar visão (nova ou trocar a consulta)")
+	fmt.Println("3. 📌 Fixar/desafixar visão no menu")
+	fmt.Println("4. 🗑️  Apagar visão")
+	fmt.Println()
+
+	switch choice := c.readInput("Escolha uma opção (Enter para voltar): "); choice {
+	case "":
+		return nil
+	case "1":
+		view, err := c.todoList.FindView(c.readInput("🔎 Nome da visão: "))
+		if err != nil {
+			return err
+		}
+		return c.showView(view, nil)
+	case "2":
+		name := c.readInput("🔎 Nome da visão: ")
+		fmt.Println("💡 Ex.: status:pending tag:infra due<=semana (datas relativas: hoje, semana, +7d)")
+		query := c.readInput("🔍 Consulta: ")
+		sortInput := c.readInput("↕️  Ordenação padrão, ex.: due:asc,priority (Enter para relevância): ")
+		return c.saveView(name, query, sortInput, false)
+	case "3":
+		return c.toggleViewPinned()
+	case "4":
+		view, err := c.todoList.FindView(c.readInput("🔎 Visão a apagar: "))
+		if err != nil {
+			return err
+		}
+		if err := c.todoList.RemoveView(view.Name); err != nil {
+			return err
+		}
+		fmt.Printf("🗑️  Visão '%s' apagada!\n", view.Name)
+		return nil
+	default:
+		return fmt.Errorf("opção inválida: %s", choice)
+	}
+}
+
+// displayViews lista as visões com a consulta e a contagem de tarefas
+func (c *CLI) displayViews() {
+	for _, view := range c.todoList.ListViews() {
+		marker := "  "
+		if view.Pinned {
+			marker = "📌"
+		}
+		order := ""
+		if view.Sort != "" {
+			order = " ↕️  " + view.Sort
+		}
+		fmt.Printf("%s 🔎 %s: %s%s — %s\n", marker, view.Name, view.Query, order, c.viewCount(view))
+	}
+}
+
+// displayPinnedViews mostra no menu principal as visões fixadas, com a
+// contagem atual de tarefas de cada uma
+func (c *CLI) displayPinnedViews() {
+	for i, view := range c.todoList.PinnedViews() {
+		fmt.Printf("%s%d. 📌 %s — %s\n", pinnedViewPrefix, i+1, view.Name, c.viewCount(view))
+	}
+}
+
+// pinnedViewChoice interpreta uma opção do menu como "v1", a primeira
+// visão fixada
+func (c *CLI) pinnedViewChoice(choice string) (task.View, bool) {
+	number, found := strings.CutPrefix(strings.ToLower(choice), pinnedViewPrefix)
+	if !found {
+		return task.View{}, false
+	}
+	n, err := strconv.Atoi(number)
+	pinned := c.todoList.PinnedViews()
+	if err != nil || n < 1 || n > len(pinned) {
+		return task.View{}, false
+	}
+	return pinned[n-1], true
+}
+
+// viewTasks executa a consulta da visão sobre as tarefas do projeto ativo,
+// na ordenação informada ou, se nil, na ordenação salva na visão
+func (c *CLI) viewTasks(view task.View, spec task.SortSpec) ([]task.Result, error) {
+	now := time.Now()
+	query, err := view.Parse(now)
+	if err != nil {
+		return nil, describeQueryError(err)
+	}
+	if spec == nil {
+		if spec, err = view.SortSpec(); err != nil {
+			return nil, err
+		}
+	}
+
+	results := query.Rank(c.todoList, c.scope().Tasks, now)
+	spec.SortResults(results)
+	return results, nil
+}
+
+// viewCount formata a quantidade de tarefas da visão
+func (c *CLI) viewCount(view task.View) string {
+	results, err := c.viewTasks(view, nil)
+	if err != nil {
+		return "⚠️  consulta inválida"
+	}
+	return fmt.Sprintf("%d tarefa(s)", len(results))
+}
+
+// showView executa a visão e exibe as tarefas encontradas (veja viewTasks)
+func (c *CLI) showView(view task.View, spec task.SortSpec) error {
+	results, err := c.viewTasks(view, spec)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("\n=== 🔎 %s ===\n", view.Name)
+	fmt.Printf("🔍 %s\n", view.Query)
+	if len(results) == 0 {
+		fmt.Println("📭 Nenhuma tarefa encontrada!")
+		return nil
+	}
+	for _, result := range results {
+		t := c.highlighted(result)
+		c.displayTaskSummary(&t)
+	}
+	return nil
+}
+
+// saveView cria ou altera uma visão, opcionalmente fixando-a no menu
+func (c *CLI) saveView(name, query, sortInput string, pin bool) error {
+	if viewActions[strings.ToLower(strings.TrimSpace(name))] {
+		return usagef("'%s' é reservado para o comando view; escolha outro nome", name)
+	}
+
+	view, created, err := c.todoList.SaveView(name, query, sortInput)
+	if err != nil {
+		return describeQueryError(err)
+	}
+	if pin {
+		if err := c.todoList.SetViewPinned(view.Name, true); err != nil {
+			return err
+		}
+	}
+
+	if created {
+		fmt.Printf("✅ Visão '%s' salva!\n", view.Name)
+	} else {
+		fmt.Printf("✏️  Consulta da visão '%s' alterada!\n", view.Name)
+	}
+	return nil
+}
+
+// toggleViewPinned fixa uma visão no menu ou desafixa uma fixada
+func (c *CLI) toggleViewPinned() error {
+	view, err := c.todoList.FindView(c.readInput("🔎 Nome da visão: "))
+	if err != nil {
+		return err
+	}
+	if err := c.todoList.SetViewPinned(view.Name, !view.Pinned); err != nil {
+		return err
+	}
+
+	if view.Pinned {
+		fmt.Printf("📍 Visão '%s' desafixada do menu!\n", view.Name)
+	} else {
+		fmt.Printf("📌 Visão '%s' fixada no menu!\n", view.Name)
+	}
+	return nil
+}
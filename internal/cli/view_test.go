@@ -0,0 +1,90 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/lucianoZgabriel/go-cli-todo/internal/storage"
+)
+
+// Os nomes das ações de "todo view" não podem virar nomes de visão, ou
+// "todo view list" deixaria de listar as visões
+func TestSaveViewReservedNames(t *testing.T) {
+	c := NewCLI(nil)
+	for name := range viewActions {
+		for _, variant := range []string{name, strings.ToUpper(name), " " + name + " "} {
+			err := c.saveView(variant, "tag:infra", "", false)
+			if exitCode(err) != ExitUsage || !strings.Contains(err.Error(), "é reservado para o comando view") {
+				t.Errorf("%q: erro %v", variant, err)
+			}
+		}
+	}
+	if len(c.todoList.Views) != 0 {
+		t.Errorf("visões salvas: %v", c.todoList.Views)
+	}
+	if err := c.saveView("listas", "tag:infra", "", false); err != nil {
+		t.Errorf("nome que só começa com uma ação: %v", err)
+	}
+}
+
+// Executar ou listar visões, e pedidos que não mudam nada, não gravam o
+// arquivo nem entram no histórico de desfazer
+func TestViewOnlySavesChanges(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tasks.json")
+	todo := func(args ...string) int {
+		t.Helper()
+		return NewCLI(storage.NewJSONStorage(path)).Run(args)
+	}
+	undoable := func() int {
+		t.Helper()
+		history, err := storage.NewJSONStorage(path).(storage.HistoryStore).LoadHistory()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return len(history.Undo)
+	}
+
+	if code := todo("view", "save", "infra", "tag:infra"); code != ExitOK {
+		t.Fatalf("view save: código %d", code)
+	}
+	saved, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tt := range []struct {
+		args []string
+		code int
+	}{
+		{[]string{"view", "infra"}, ExitOK},
+		{[]string{"view", "infra", "--sort", "title", "-o", "json"}, ExitOK},
+		{[]string{"view"}, ExitOK},
+		{[]string{"view", "unpin", "infra"}, ExitOK},
+		{[]string{"view", "save", "INFRA", "tag:infra"}, ExitOK},
+		{[]string{"view", "rm", "nenhuma"}, ExitNotFound},
+		{[]string{"view", "save", "pin", "tag:infra"}, ExitUsage},
+	} {
+		if code := todo(tt.args...); code != tt.code {
+			t.Errorf("%q: código %d, esperado %d", tt.args, code, tt.code)
+		}
+		if current, _ := os.ReadFile(path); string(current) != string(saved) {
+			t.Fatalf("%q gravou o arquivo", tt.args)
+		}
+		if n := undoable(); n != 1 {
+			t.Fatalf("%q: %d operações para desfazer, esperado 1", tt.args, n)
+		}
+	}
+
+	// Uma alteração de verdade grava e pode ser desfeita
+	if code := todo("view", "pin", "infra"); code != ExitOK {
+		t.Fatalf("view pin: código %d", code)
+	}
+	if current, _ := os.ReadFile(path); string(current) == string(saved) {
+		t.Error("view pin não gravou o arquivo")
+	}
+	if n := undoable(); n != 2 {
+		t.Errorf("%d operações para desfazer, esperado 2", n)
+	}
+}
@@ -0,0 +1,106 @@
+package output
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strings"
+)
+
+// jsonFormatter escreve um array JSON (ou um objeto, para um único item)
+type jsonFormatter struct{}
+
+func (jsonFormatter) WriteList(w io.Writer, _ []string, records []Record) error {
+	if records == nil {
+		records = []Record{}
+	}
+	return writeJSON(w, records)
+}
+
+func (jsonFormatter) WriteItem(w io.Writer, _ []string, record Record) error {
+	return writeJSON(w, record)
+}
+
+// writeJSON escreve v indentado, seguido de quebra de linha
+func writeJSON(w io.Writer, v any) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
+
+// ndjsonFormatter escreve um objeto JSON por linha
+type ndjsonFormatter struct{}
+
+func (ndjsonFormatter) WriteList(w io.Writer, _ []string, records []Record) error {
+	encoder := json.NewEncoder(w)
+	for _, record := range records {
+		if err := encoder.Encode(record); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f ndjsonFormatter) WriteItem(w io.Writer, columns []string, record Record) error {
+	return f.WriteList(w, columns, []Record{record})
+}
+
+// delimitedFormatter escreve CSV ou TSV com cabeçalho. escape, se
+// definido, é aplicado a cada valor antes da gravação.
+type delimitedFormatter struct {
+	separator rune
+	escape    func(string) string
+}
+
+func (f delimitedFormatter) WriteList(w io.Writer, columns []string, records []Record) error {
+	if f.escape != nil {
+		return f.writePlain(w, columns, records)
+	}
+
+	writer := csv.NewWriter(w)
+	writer.Comma = f.separator
+	if err := writer.Write(columns); err != nil {
+		return err
+	}
+	for _, record := range records {
+		if err := writer.Write(record.Values()); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+func (f delimitedFormatter) WriteItem(w io.Writer, columns []string, record Record) error {
+	return f.WriteList(w, columns, []Record{record})
+}
+
+// writePlain escreve valores escapados, sem as aspas do CSV
+func (f delimitedFormatter) writePlain(w io.Writer, columns []string, records []Record) error {
+	writeRow := func(values []string) error {
+		escaped := make([]string, len(values))
+		for i, value := range values {
+			escaped[i] = f.escape(value)
+		}
+		_, err := io.WriteString(w, strings.Join(escaped, string(f.separator))+"\n")
+		return err
+	}
+
+	if err := writeRow(columns); err != nil {
+		return err
+	}
+	for _, record := range records {
+		if err := writeRow(record.Values()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// tsvEscaper escapa os caracteres que quebrariam uma linha de TSV
+var tsvEscaper = strings.NewReplacer(`\`, `\\`, "\t", `\t`, "\n", `\n`, "\r", `\r`)
+
+// escapeTSV escapa um valor para TSV
+func escapeTSV(value string) string {
+	return tsvEscaper.Replace(value)
+}
@@ -0,0 +1,129 @@
+package output
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/lucianoZgabriel/go-cli-todo/internal/task"
+)
+
+// update regrava os arquivos esperados em testdata: go test -update
+var update = flag.Bool("update", false, "regrava os arquivos de testdata")
+
+// golden compara a saída com testdata/<name>, ou o regrava com -update
+func golden(t *testing.T, name string, got []byte) {
+	t.Helper()
+	path := filepath.Join("testdata", name)
+	if *update {
+		if err := os.WriteFile(path, got, 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("%s difere do esperado\nobtido:\n%s\nesperado:\n%s", name, got, want)
+	}
+}
+
+// sampleRecords monta uma tarefa com todos os campos e outra com texto
+// que precisa de escape em cada formato
+func sampleRecords(t *testing.T) []Record {
+	t.Helper()
+	created := time.Date(2026, time.October, 1, 9, 30, 15, 500, time.UTC)
+	updated := created.Add(26 * time.Hour)
+	due := time.Date(2026, time.November, 3, 22, 0, 0, 0, time.UTC)
+	recurrence, err := task.ParseRecurrence("weekly:mon,fri")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	deploy := task.Task{
+		ID: 7, Title: "Deploy da API", Description: "janela das 22h",
+		Completed: true, CompletedAt: &updated,
+		Priority: task.PriorityHigh, DueDate: &due, DueHasTime: true,
+		Tags: []string{"infra", "urgente"}, ParentID: 2, BlockedBy: []int{3, 5},
+		Recurrence: recurrence, CreatedAt: created, UpdatedAt: &updated,
+	}
+	dueDay := time.Date(2026, time.November, 10, 0, 0, 0, 0, time.UTC)
+	escaped := task.Task{
+		ID: 8, Title: `Revisar "deploy", de novo`,
+		Description: "passos:\n1.\tbuild\r\n2. C:\\temp; ação",
+		DueDate:     &dueDay, CreatedAt: created,
+	}
+	return []Record{
+		NewTaskRecord(deploy, "trabalho"),
+		NewTaskRecord(escaped, "Inbox"),
+	}
+}
+
+func TestFormatTasks(t *testing.T) {
+	records := sampleRecords(t)
+	for format, name := range map[Format]string{
+		JSON:   "tasks.json",
+		NDJSON: "tasks.ndjson",
+		CSV:    "tasks.csv",
+		TSV:    "tasks.tsv",
+	} {
+		var out bytes.Buffer
+		if err := WriteList(&out, format, TaskColumns, records); err != nil {
+			t.Fatalf("%s: %v", format, err)
+		}
+		golden(t, name, out.Bytes())
+	}
+}
+
+// Listas vazias continuam sendo JSON válido e CSV/TSV com cabeçalho
+func TestFormatEmptyList(t *testing.T) {
+	for format, want := range map[Format]string{
+		JSON:   "[]\n",
+		NDJSON: "",
+		CSV:    "project,total,completed,pending,overdue,due_today,due_this_week\n",
+		TSV:    "project\ttotal\tcompleted\tpending\toverdue\tdue_today\tdue_this_week\n",
+	} {
+		var out bytes.Buffer
+		if err := WriteList(&out, format, StatsColumns, nil); err != nil {
+			t.Fatalf("%s: %v", format, err)
+		}
+		if out.String() != want {
+			t.Errorf("%s: %q, esperado %q", format, out.String(), want)
+		}
+	}
+}
+
+func TestFormatItems(t *testing.T) {
+	project := "trabalho"
+	search := SearchRecord{TaskRecord: sampleRecords(t)[1].(TaskRecord), Score: 42}
+	for name, record := range map[string]Record{
+		"search":        search,
+		"stats":         StatsRecord{Total: 5, Completed: 2, Pending: 3, Overdue: 1, DueToday: 1, DueThisWeek: 2},
+		"stats-project": StatsRecord{Project: &project, Total: 1, Pending: 1},
+	} {
+		for format, ext := range map[Format]string{JSON: ".json", NDJSON: ".ndjson", CSV: ".csv", TSV: ".tsv"} {
+			var out bytes.Buffer
+			if err := WriteItem(&out, format, record); err != nil {
+				t.Fatalf("%s %s: %v", name, format, err)
+			}
+			golden(t, name+ext, out.Bytes())
+		}
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	for input, want := range map[string]Format{"": Text, "text": Text, " JSON ": JSON, "tsv": TSV} {
+		if format, err := ParseFormat(input); err != nil || format != want {
+			t.Errorf("%q: %q, erro %v, esperado %q", input, format, err, want)
+		}
+	}
+	_, err := ParseFormat("xml")
+	if want := "formato de saída inválido: xml (use text, csv, json, ndjson, tsv)"; err == nil || err.Error() != want {
+		t.Errorf("formato desconhecido: erro %v, esperado %q", err, want)
+	}
+}
@@ -0,0 +1,130 @@
+// Package output gera saídas legíveis por máquina (JSON, NDJSON, CSV e TSV)
+// para os comandos de consulta. A saída para humanos, com emojis, continua
+// na camada cli; aqui os campos são fixos e documentados para uso em
+// scripts.
+//
+// Campos de uma tarefa (TaskRecord), na ordem das colunas:
+//
+//	id            número da tarefa
+//	title         título
+//	description   descrição (pode ter várias linhas)
+//	status        "pending" ou "done"
+//	priority      none, low, medium, high ou urgent
+//	due           prazo: AAAA-MM-DD, ou RFC 3339 quando tem horário
+//	tags          tags, sem "#" (lista em JSON; separadas por vírgula em CSV/TSV)
+//	project       nome do projeto
+//	parent_id     tarefa principal, se for subtarefa
+//	blocked_by    IDs das tarefas de que depende (lista em JSON)
+//	recurrence    regra de repetição (ex.: weekly:mon,fri)
+//	created_at    criação (RFC 3339)
+//	updated_at    última alteração (RFC 3339)
+//	completed_at  conclusão (RFC 3339)
+//
+// Resultados de busca (SearchRecord) trazem os mesmos campos e, por último,
+// score: a relevância do resultado (maior é mais relevante).
+//
+// Campos das estatísticas (StatsRecord): project, total, completed,
+// pending, overdue, due_today e due_this_week.
+//
+// Em JSON, campos sem valor são null e listas vazias são []. Em CSV e TSV,
+// a primeira linha é o cabeçalho e campos sem valor ficam vazios; no TSV,
+// tabulações, quebras de linha e barras invertidas nos valores são
+// escapadas como \t, \n e \\.
+package output
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// Format identifica um formato de saída
+type Format string
+
+// Formatos disponíveis. Text é a saída para humanos, gerada pela cli.
+const (
+	Text   Format = "text"
+	JSON   Format = "json"
+	NDJSON Format = "ndjson"
+	CSV    Format = "csv"
+	TSV    Format = "tsv"
+)
+
+// Record é um item da saída: serializado como objeto em JSON e como linha
+// nos formatos tabulares
+type Record interface {
+	// Columns retorna os nomes dos campos, na ordem das colunas
+	Columns() []string
+
+	// Values retorna os valores em texto, na ordem de Columns
+	Values() []string
+}
+
+// Formatter escreve registros em um formato. columns é o cabeçalho usado
+// pelos formatos tabulares, inclusive quando não há registros.
+type Formatter interface {
+	// WriteList escreve uma lista de registros
+	WriteList(w io.Writer, columns []string, records []Record) error
+
+	// WriteItem escreve um único registro (ex.: o comando show)
+	WriteItem(w io.Writer, columns []string, record Record) error
+}
+
+// formatters associa cada formato à sua implementação. O mapa só é lido
+// depois da inicialização; novos formatos entram nesta lista.
+var formatters = map[Format]Formatter{
+	JSON:   jsonFormatter{},
+	NDJSON: ndjsonFormatter{},
+	CSV:    delimitedFormatter{separator: ','},
+	TSV:    delimitedFormatter{separator: '\t', escape: escapeTSV},
+}
+
+// Names retorna os formatos aceitos, começando por text
+func Names() []string {
+	names := []string{string(Text)}
+	for format := range formatters {
+		names = append(names, string(format))
+	}
+	sort.Strings(names[1:])
+	return names
+}
+
+// ParseFormat interpreta o nome de um formato. Vazio equivale a Text.
+func ParseFormat(name string) (Format, error) {
+	format := Format(strings.ToLower(strings.TrimSpace(name)))
+	if format == "" || format == Text {
+		return Text, nil
+	}
+	if _, ok := formatters[format]; !ok {
+		return "", fmt.Errorf("formato de saída inválido: %s (use %s)", name, strings.Join(Names(), ", "))
+	}
+	return format, nil
+}
+
+// WriteList escreve os registros no formato informado
+func WriteList(w io.Writer, format Format, columns []string, records []Record) error {
+	formatter, err := lookup(format)
+	if err != nil {
+		return err
+	}
+	return formatter.WriteList(w, columns, records)
+}
+
+// WriteItem escreve um único registro no formato informado
+func WriteItem(w io.Writer, format Format, record Record) error {
+	formatter, err := lookup(format)
+	if err != nil {
+		return err
+	}
+	return formatter.WriteItem(w, record.Columns(), record)
+}
+
+// lookup busca o formatter de um formato legível por máquina
+func lookup(format Format) (Formatter, error) {
+	formatter, ok := formatters[format]
+	if !ok {
+		return nil, fmt.Errorf("formato de saída sem formatter: %s", format)
+	}
+	return formatter, nil
+}
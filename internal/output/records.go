@@ -0,0 +1,190 @@
+package output
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lucianoZgabriel/go-cli-todo/internal/task"
+)
+
+// dueDateLayout formata prazos sem horário
+const dueDateLayout = "2006-01-02"
+
+// TaskColumns são as colunas de TaskRecord, na ordem da saída
+var TaskColumns = []string{
+	"id", "title", "description", "status", "priority", "due", "tags", "project",
+	"parent_id", "blocked_by", "recurrence", "created_at", "updated_at", "completed_at",
+}
+
+// TaskRecord é a representação estável de uma tarefa na saída
+type TaskRecord struct {
+	ID          int        `json:"id"`
+	Title       string     `json:"title"`
+	Description string     `json:"description"`
+	Status      string     `json:"status"`
+	Priority    string     `json:"priority"`
+	Due         *string    `json:"due"`
+	Tags        []string   `json:"tags"`
+	Project     string     `json:"project"`
+	ParentID    *int       `json:"parent_id"`
+	BlockedBy   []int      `json:"blocked_by"`
+	Recurrence  *string    `json:"recurrence"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   *time.Time `json:"updated_at"`
+	CompletedAt *time.Time `json:"completed_at"`
+}
+
+// NewTaskRecord converte uma tarefa; project é o nome do seu projeto
+func NewTaskRecord(t task.Task, project string) TaskRecord {
+	record := TaskRecord{
+		ID:          t.ID,
+		Title:       t.Title,
+		Description: t.Description,
+		Status:      "pending",
+		Priority:    t.Priority.String(),
+		Tags:        append([]string{}, t.Tags...),
+		Project:     project,
+		BlockedBy:   append([]int{}, t.BlockedBy...),
+		CreatedAt:   t.CreatedAt.Truncate(time.Second),
+		UpdatedAt:   truncate(t.UpdatedAt),
+		CompletedAt: truncate(t.CompletedAt),
+	}
+	if t.Completed {
+		record.Status = "done"
+	}
+	if t.DueDate != nil {
+		due := t.DueDate.Format(dueDateLayout)
+		if t.DueHasTime {
+			due = t.DueDate.Format(time.RFC3339)
+		}
+		record.Due = &due
+	}
+	if t.ParentID != 0 {
+		parentID := t.ParentID
+		record.ParentID = &parentID
+	}
+	if t.Recurrence != nil {
+		rule := t.Recurrence.String()
+		record.Recurrence = &rule
+	}
+	return record
+}
+
+// Columns implementa Record
+func (r TaskRecord) Columns() []string {
+	return TaskColumns
+}
+
+// Values implementa Record
+func (r TaskRecord) Values() []string {
+	blockedBy := make([]string, len(r.BlockedBy))
+	for i, id := range r.BlockedBy {
+		blockedBy[i] = strconv.Itoa(id)
+	}
+
+	return []string{
+		strconv.Itoa(r.ID),
+		r.Title,
+		r.Description,
+		r.Status,
+		r.Priority,
+		stringValue(r.Due),
+		strings.Join(r.Tags, ","),
+		r.Project,
+		intValue(r.ParentID),
+		strings.Join(blockedBy, ","),
+		stringValue(r.Recurrence),
+		r.CreatedAt.Format(time.RFC3339),
+		timeValue(r.UpdatedAt),
+		timeValue(r.CompletedAt),
+	}
+}
+
+// SearchColumns são as colunas de SearchRecord: as de uma tarefa mais a
+// pontuação da busca
+var SearchColumns = append(append([]string{}, TaskColumns...), "score")
+
+// SearchRecord é uma tarefa encontrada pela busca, com a sua relevância
+type SearchRecord struct {
+	TaskRecord
+	Score int `json:"score"`
+}
+
+// Columns implementa Record
+func (r SearchRecord) Columns() []string {
+	return SearchColumns
+}
+
+// Values implementa Record
+func (r SearchRecord) Values() []string {
+	return append(r.TaskRecord.Values(), strconv.Itoa(r.Score))
+}
+
+// StatsColumns são as colunas de StatsRecord, na ordem da saída
+var StatsColumns = []string{
+	"project", "total", "completed", "pending", "overdue", "due_today", "due_this_week",
+}
+
+// StatsRecord resume as tarefas de um projeto, ou de todos se Project for nil
+type StatsRecord struct {
+	Project     *string `json:"project"`
+	Total       int     `json:"total"`
+	Completed   int     `json:"completed"`
+	Pending     int     `json:"pending"`
+	Overdue     int     `json:"overdue"`
+	DueToday    int     `json:"due_today"`
+	DueThisWeek int     `json:"due_this_week"`
+}
+
+// Columns implementa Record
+func (r StatsRecord) Columns() []string {
+	return StatsColumns
+}
+
+// Values implementa Record
+func (r StatsRecord) Values() []string {
+	return []string{
+		stringValue(r.Project),
+		strconv.Itoa(r.Total),
+		strconv.Itoa(r.Completed),
+		strconv.Itoa(r.Pending),
+		strconv.Itoa(r.Overdue),
+		strconv.Itoa(r.DueToday),
+		strconv.Itoa(r.DueThisWeek),
+	}
+}
+
+// truncate descarta as frações de segundo, para que JSON e CSV mostrem
+// as mesmas datas
+func truncate(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	truncated := t.Truncate(time.Second)
+	return &truncated
+}
+
+// stringValue formata um texto opcional
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+// intValue formata um número opcional
+func intValue(n *int) string {
+	if n == nil {
+		return ""
+	}
+	return strconv.Itoa(*n)
+}
+
+// timeValue formata uma data opcional em RFC 3339
+func timeValue(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
@@ -1,15 +1,51 @@
 package storage
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"os"
+	"path/filepath"
+	"time"
 
 	"github.com/lucianoZgabriel/go-cli-todo/internal/task"
 )
 
+// backupSuffix é a extensão do arquivo com a versão anterior dos dados
+const backupSuffix = ".bak"
+
+// defaultFileMode é a permissão usada ao criar o arquivo pela primeira vez
+const defaultFileMode os.FileMode = 0o644
+
+// historySuffix é a extensão do arquivo com o histórico de desfazer/refazer
+const historySuffix = ".history"
+
+// lockSuffix é a extensão do arquivo usado para o lock entre processos. O
+// lock não pode ficar no próprio arquivo de dados, que é substituído a
+// cada Save.
+const lockSuffix = ".lock"
+
+// document é o formato gravado em disco: a TodoList acrescida da versão do
+// formato (veja migration.go) e do número da revisão, incrementado a cada
+// Save, usado para detectar gravações concorrentes. Arquivos antigos, sem
+// esses campos, estão na versão e na revisão zero.
+type document struct {
+	Version  int `json:"version"`
+	Revision int `json:"revision"`
+	*task.TodoList
+}
+
 // JSONStorage implementa a interface Storage usando arquivos JSON
 type JSONStorage struct {
 	filename string
+
+	// revision e base descrevem o arquivo como estava no último Load/Save
+	revision int
+	base     []byte
+
+	// recovered guarda o motivo pelo qual o último Load usou o backup
+	recovered error
 }
 
 // NewJSONStorage cria um novo storage JSON com o arquivo especificado
@@ -19,39 +55,369 @@ func NewJSONStorage(filename string) Storage {
 	}
 }
 
-// Save persiste a TodoList em arquivo JSON
+// Save persiste a TodoList em arquivo JSON de forma atômica: os dados são
+// gravados em um arquivo temporário no mesmo diretório, sincronizados em
+// disco e então renomeados sobre o original. Uma falha no meio da escrita
+// nunca deixa o arquivo principal truncado. A versão anterior, se válida,
+// é mantida em "<arquivo>.bak".
+//
+// Se outro processo gravou o arquivo desde o último Load, nada é salvo e
+// um *ConflictError é retornado.
 func (js *JSONStorage) Save(todoList *task.TodoList) error {
-	file, err := os.Create(js.filename)
+	unlock, err := js.lock(true)
 	if err != nil {
 		return err
 	}
-	defer file.Close()
+	defer unlock()
 
-	encoder := json.NewEncoder(file)
-	encoder.SetIndent("", " ") // Formatação JSON
+	current, err := readIfExists(js.filename)
+	if err != nil {
+		return err
+	}
+	if err := js.checkRevision(current); err != nil {
+		return err
+	}
+
+	data, err := encodeDocument(todoList, js.revision+1)
+	if err != nil {
+		return err
+	}
+
+	mode := defaultFileMode
+	if info, err := os.Stat(js.filename); err == nil {
+		mode = info.Mode().Perm()
+	}
+
+	if err := js.backup(current, mode); err != nil {
+		return fmt.Errorf("erro ao criar backup: %w", err)
+	}
+	if _, err := js.versionBackup(current, mode); err != nil {
+		return fmt.Errorf("erro ao criar backup: %w", err)
+	}
+	if err := writeFileAtomic(js.filename, data, mode); err != nil {
+		return err
+	}
+
+	js.revision++
+	js.base = data
+	js.recovered = nil
+	return nil
+}
+
+// Migrate atualiza o arquivo para o formato atual, guardando antes uma
+// cópia do original em "<arquivo>.v<versão>.bak". Com dryRun, apenas
+// informa as migrações pendentes.
+func (js *JSONStorage) Migrate(dryRun bool) (*MigrationReport, error) {
+	unlock, err := js.lock(true)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	current, err := readIfExists(js.filename)
+	if err != nil {
+		return nil, err
+	}
+	if current == nil {
+		return &MigrationReport{From: CurrentVersion, To: CurrentVersion}, nil
+	}
+
+	_, report, err := upgradeDocument(current)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao migrar '%s': %w", js.filename, err)
+	}
+	if dryRun || len(report.Steps) == 0 {
+		return report, nil
+	}
+
+	todoList, revision, err := decodeDocument(current)
+	if err != nil {
+		return nil, err
+	}
+	data, err := encodeDocument(todoList, revision+1)
+	if err != nil {
+		return nil, err
+	}
+
+	mode := defaultFileMode
+	if info, err := os.Stat(js.filename); err == nil {
+		mode = info.Mode().Perm()
+	}
+	if report.Backup, err = js.versionBackup(current, mode); err != nil {
+		return nil, fmt.Errorf("erro ao criar backup: %w", err)
+	}
+	if err := writeFileAtomic(js.filename, data, mode); err != nil {
+		return nil, err
+	}
+
+	// O conteúdo não mudou para quem carregou a revisão migrada
+	if js.revision == revision {
+		js.revision++
+		js.base = data
+	}
+	return report, nil
+}
+
+// SaveHistory grava o histórico de desfazer/refazer em "<arquivo>.history"
+func (js *JSONStorage) SaveHistory(history *task.History) error {
+	unlock, err := js.lock(true)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	data, err := json.Marshal(history)
+	if err != nil {
+		return err
+	}
+
+	mode := defaultFileMode
+	if info, err := os.Stat(js.filename); err == nil {
+		mode = info.Mode().Perm()
+	}
+	return writeFileAtomic(js.filename+historySuffix, data, mode)
+}
+
+// LoadHistory lê o histórico de desfazer/refazer; sem arquivo, retorna
+// um histórico vazio
+func (js *JSONStorage) LoadHistory() (*task.History, error) {
+	unlock, err := js.lock(false)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	history := task.NewHistory(task.DefaultHistoryDepth)
+	data, err := readIfExists(js.filename + historySuffix)
+	if err != nil || data == nil {
+		return history, err
+	}
+	if err := json.Unmarshal(data, history); err != nil {
+		return nil, fmt.Errorf("erro ao decodificar '%s': %w", js.filename+historySuffix, err)
+	}
+	return history, nil
+}
+
+// checkRevision compara a revisão em disco com a do último Load. Em caso
+// de conflito, passa a usar a versão em disco como base. Um arquivo
+// ilegível não é considerado conflito: o Save o substitui.
+func (js *JSONStorage) checkRevision(current []byte) error {
+	theirs, revision, err := decodeDocument(current)
+	if errors.Is(err, ErrNewerVersion) {
+		return err
+	}
+	if err != nil || revision == js.revision {
+		return nil
+	}
+
+	base, _, err := decodeDocument(js.base)
+	if err != nil {
+		base = task.NewTodoList()
+	}
+
+	js.revision = revision
+	js.base = current
+	return &ConflictError{Base: base, Theirs: theirs}
+}
+
+// lock obtém o lock entre processos do arquivo de dados e retorna a
+// função que o libera
+func (js *JSONStorage) lock(exclusive bool) (func(), error) {
+	f, err := os.OpenFile(js.filename+lockSuffix, os.O_RDWR|os.O_CREATE, defaultFileMode)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao abrir lock: %w", err)
+	}
+	if err := lockFile(f, exclusive); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("erro ao obter lock: %w", err)
+	}
+
+	return func() {
+		unlockFile(f)
+		f.Close()
+	}, nil
+}
+
+// backup copia o conteúdo atual para o ".bak" antes de sobrescrevê-lo. Um
+// arquivo principal corrompido não substitui um backup bom.
+func (js *JSONStorage) backup(current []byte, mode os.FileMode) error {
+	if current == nil || !json.Valid(current) {
+		return nil
+	}
+	return writeFileAtomic(js.filename+backupSuffix, current, mode)
+}
+
+// versionBackup guarda uma cópia de um arquivo em formato antigo antes que
+// ele seja substituído pelo formato atual. A cópia de cada versão é feita
+// uma única vez, preservando o original. Retorna o caminho da cópia.
+func (js *JSONStorage) versionBackup(current []byte, mode os.FileMode) (string, error) {
+	var header struct {
+		Version int `json:"version"`
+	}
+	if current == nil || json.Unmarshal(current, &header) != nil || header.Version >= CurrentVersion {
+		return "", nil
+	}
 
-	return encoder.Encode(todoList)
+	path := fmt.Sprintf("%s.v%d%s", js.filename, header.Version, backupSuffix)
+	if _, err := os.Stat(path); err == nil {
+		return path, nil
+	}
+	return path, writeFileAtomic(path, current, mode)
 }
 
-// Load carrega uma TodoList do arquivo JSON
+// Load carrega uma TodoList do arquivo JSON. Se o arquivo principal não
+// puder ser lido ou decodificado, tenta o backup da versão anterior.
 func (js *JSONStorage) Load() (*task.TodoList, error) {
+	unlock, err := js.lock(false)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	js.recovered = nil
+
+	todoList, err := js.read(js.filename)
+	if err == nil {
+		return todoList, nil
+	}
+
 	// Se arquivo não existe, retorna lista vazia
-	if _, err := os.Stat(js.filename); os.IsNotExist(err) {
+	if errors.Is(err, os.ErrNotExist) {
+		js.revision, js.base = 0, nil
 		return task.NewTodoList(), nil
 	}
 
-	file, err := os.Open(js.filename)
+	// Dados de uma versão mais nova não estão corrompidos: o backup seria
+	// uma versão desatualizada deles
+	if errors.Is(err, ErrNewerVersion) {
+		return nil, err
+	}
+
+	backupList, backupErr := js.read(js.filename + backupSuffix)
+	if backupErr != nil {
+		return nil, err
+	}
+
+	js.recovered = fmt.Errorf("%v; dados restaurados de '%s'", err, js.filename+backupSuffix)
+	return backupList, nil
+}
+
+// read carrega um arquivo e registra sua revisão como base para o Save
+func (js *JSONStorage) read(filename string) (*task.TodoList, error) {
+	data, err := os.ReadFile(filename)
 	if err != nil {
 		return nil, err
 	}
-	defer file.Close()
 
-	var todoList task.TodoList
-	decoder := json.NewDecoder(file)
+	todoList, revision, err := decodeDocument(data)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao decodificar '%s': %w", filename, err)
+	}
+
+	js.revision = revision
+	js.base = data
+	return todoList, nil
+}
+
+// Recovered informa se o último Load precisou usar o backup, e por quê
+func (js *JSONStorage) Recovered() error {
+	return js.recovered
+}
+
+// decodeDocument decodifica o conteúdo de um arquivo de dados, migrando-o
+// para o formato atual se necessário; conteúdo vazio corresponde a uma
+// lista nova, na revisão zero
+func decodeDocument(data []byte) (*task.TodoList, int, error) {
+	doc := document{TodoList: task.NewTodoList()}
+	if len(data) == 0 {
+		return doc.TodoList, 0, nil
+	}
 
-	if err := decoder.Decode(&todoList); err != nil {
+	data, _, err := upgradeDocument(data)
+	if err != nil {
+		return nil, 0, err
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, 0, err
+	}
+
+	// Tarefas da lixeira sem data de remoção (arquivo editado à mão) contam
+	// como removidas agora: a lixeira e a limpeza dependem dessa data
+	now := time.Now()
+	for i := range doc.Trash {
+		if doc.Trash[i].DeletedAt == nil {
+			doc.Trash[i].DeletedAt = &now
+		}
+	}
+	return doc.TodoList, doc.Revision, nil
+}
+
+// encodeDocument serializa a lista no formato atual, na revisão informada
+func encodeDocument(todoList *task.TodoList, revision int) ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetIndent("", " ") // Formatação JSON
+
+	doc := document{Version: CurrentVersion, Revision: revision, TodoList: todoList}
+	if err := encoder.Encode(doc); err != nil {
 		return nil, err
 	}
+	return buf.Bytes(), nil
+}
+
+// readIfExists lê o arquivo; um arquivo inexistente retorna nil sem erro
+func readIfExists(filename string) ([]byte, error) {
+	data, err := os.ReadFile(filename)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	return data, err
+}
+
+// writeFileAtomic grava data em filename via arquivo temporário + rename
+func writeFileAtomic(filename string, data []byte, mode os.FileMode) (err error) {
+	dir := filepath.Dir(filename)
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(filename)+".tmp-*")
+	if err != nil {
+		return err
+	}
 
-	return &todoList, nil
+	// Em caso de erro, o temporário é descartado e o original fica intacto
+	defer func() {
+		if err != nil {
+			tmp.Close()
+			os.Remove(tmp.Name())
+		}
+	}()
+
+	if _, err = tmp.Write(data); err != nil {
+		return err
+	}
+	if err = tmp.Sync(); err != nil {
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+	if err = os.Chmod(tmp.Name(), mode); err != nil {
+		return err
+	}
+	if err = os.Rename(tmp.Name(), filename); err != nil {
+		return err
+	}
+
+	syncDir(dir)
+	return nil
+}
+
+// syncDir sincroniza o diretório para que o rename sobreviva a uma queda
+// de energia. Nem todo sistema permite sincronizar diretórios, então
+// falhas aqui são ignoradas.
+func syncDir(dir string) {
+	d, err := os.Open(dir)
+	if err != nil {
+		return
+	}
+	defer d.Close()
+	d.Sync()
 }
@@ -0,0 +1,181 @@
+package storage
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/lucianoZgabriel/go-cli-todo/internal/task"
+)
+
+// O histórico de desfazer/refazer fica em "<arquivo>.history" e sobrevive
+// entre execuções
+func TestJSONHistoryFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tasks.json")
+	s := NewJSONStorage(path).(*JSONStorage)
+
+	history, err := s.LoadHistory()
+	if err != nil || len(history.Undo) > 0 || history.Depth != task.DefaultHistoryDepth {
+		t.Fatalf("histórico sem arquivo: %+v, erro %v", history, err)
+	}
+
+	tl := task.NewTodoList()
+	if err := history.Record(tl, "", func() error { tl.AddTask("deploy", ""); return nil }); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.SaveHistory(history); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(path + historySuffix); err != nil {
+		t.Fatalf("arquivo do histórico: %v", err)
+	}
+
+	loaded, err := NewJSONStorage(path).(*JSONStorage).LoadHistory()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(loaded.Undo) != 1 || loaded.Undo[0].Label != "criar [1] deploy" {
+		t.Fatalf("histórico relido: %+v", loaded.Undo)
+	}
+	if _, err := loaded.UndoLast(tl); err != nil || len(tl.Tasks) != 0 {
+		t.Errorf("desfazer com o histórico relido: %v, tarefas %v", err, tl.Tasks)
+	}
+
+	if err := os.WriteFile(path+historySuffix, []byte("{"), defaultFileMode); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.LoadHistory(); err == nil {
+		t.Error("histórico corrompido foi aceito")
+	}
+}
+
+// Uma tarefa na lixeira sem data de remoção conta como removida agora, em
+// vez de quebrar a listagem e a limpeza da lixeira
+func TestJSONTrashWithoutDeletedAt(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tasks.json")
+	data := `{"version":2,"tasks":[],"next_id":3,"trash":[
+		{"id":1,"title":"sem data","created_at":"2026-10-01T10:00:00Z"},
+		{"id":2,"title":"antiga","created_at":"2026-08-01T10:00:00Z","deleted_at":"2026-08-02T10:00:00Z"}]}`
+	if err := os.WriteFile(path, []byte(data), defaultFileMode); err != nil {
+		t.Fatal(err)
+	}
+
+	before := time.Now()
+	todoList, err := NewJSONStorage(path).Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if deletedAt := todoList.Trash[0].DeletedAt; deletedAt == nil || deletedAt.Before(before) {
+		t.Fatalf("data de remoção = %v, esperado o momento do Load", deletedAt)
+	}
+	if trashed := todoList.TrashedTasks(); trashed[0].ID != 1 {
+		t.Errorf("lixeira começa pela tarefa %d, esperado 1", trashed[0].ID)
+	}
+	if purged := todoList.PurgeTrash(before.AddDate(0, 0, -task.DefaultTrashRetentionDays)); purged != 1 {
+		t.Errorf("apagadas %d, esperado só a antiga", purged)
+	}
+}
+
+// saveTitles grava uma lista com as tarefas informadas
+func saveTitles(t *testing.T, s Storage, titles ...string) {
+	t.Helper()
+	tl := task.NewTodoList()
+	for _, title := range titles {
+		tl.AddTask(title, "")
+	}
+	if err := s.Save(tl); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestJSONLoadFallsBackToBackup(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tasks.json")
+	s := NewJSONStorage(path)
+	saveTitles(t, s, "deploy")
+	saveTitles(t, s, "deploy", "backup") // .bak guarda a primeira versão
+
+	// Arquivo truncado no meio da escrita por outro programa
+	data, _ := os.ReadFile(path)
+	if err := os.WriteFile(path, data[:len(data)/2], defaultFileMode); err != nil {
+		t.Fatal(err)
+	}
+
+	js := NewJSONStorage(path).(*JSONStorage)
+	todoList, err := js.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if titles := titlesOf(todoList); len(titles) != 1 || titles[0] != "deploy" {
+		t.Errorf("tarefas recuperadas: %v, esperado a versão do backup", titles)
+	}
+	if js.Recovered() == nil {
+		t.Error("Recovered não informou o uso do backup")
+	}
+
+	// Sem backup válido, o erro do arquivo principal é retornado
+	if err := os.WriteFile(path+backupSuffix, []byte("{"), defaultFileMode); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewJSONStorage(path).Load(); err == nil {
+		t.Error("arquivo e backup corrompidos foram aceitos")
+	}
+}
+
+// Dados de uma versão mais nova do programa não são trocados pelo backup
+func TestJSONLoadNewerVersionSkipsBackup(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tasks.json")
+	s := NewJSONStorage(path)
+	saveTitles(t, s, "deploy")
+	saveTitles(t, s, "deploy", "backup")
+	if err := os.WriteFile(path, []byte(`{"version":99,"tasks":[]}`), defaultFileMode); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := NewJSONStorage(path).Load(); !errors.Is(err, ErrNewerVersion) {
+		t.Errorf("erro %v, esperado ErrNewerVersion", err)
+	}
+}
+
+// Uma gravação que falha no meio não altera o arquivo nem deixa
+// temporários para trás
+func TestJSONFailedSaveKeepsFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "tasks.json")
+	s := NewJSONStorage(path)
+	saveTitles(t, s, "deploy")
+	original, _ := os.ReadFile(path)
+
+	// Um diretório no lugar do .bak faz a troca de arquivos falhar
+	if err := os.Remove(path + backupSuffix); err != nil && !os.IsNotExist(err) {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(path+backupSuffix, "ocupado"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	tl, err := s.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	tl.AddTask("backup", "")
+	if err := s.Save(tl); err == nil {
+		t.Fatal("Save deveria falhar")
+	}
+
+	if current, _ := os.ReadFile(path); string(current) != string(original) {
+		t.Errorf("arquivo alterado pela gravação que falhou:\n%s", current)
+	}
+	if temps, _ := filepath.Glob(filepath.Join(dir, ".*.tmp-*")); len(temps) > 0 {
+		t.Errorf("temporários deixados: %v", temps)
+	}
+
+	// O arquivo continua legível e o storage continua podendo salvar
+	if err := os.RemoveAll(path + backupSuffix); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Save(tl); err != nil {
+		t.Errorf("Save após a falha: %v", err)
+	}
+}
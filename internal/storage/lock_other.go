@@ -0,0 +1,16 @@
+//go:build !unix
+
+package storage
+
+import "os"
+
+// lockFile não faz nada em sistemas sem flock; a verificação de revisão
+// no Save continua detectando gravações concorrentes
+func lockFile(f *os.File, exclusive bool) error {
+	return nil
+}
+
+// unlockFile não faz nada em sistemas sem flock
+func unlockFile(f *os.File) error {
+	return nil
+}
@@ -0,0 +1,218 @@
+package storage
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// CurrentVersion é a versão do formato do arquivo JSON gravado por este
+// programa. Deve ser igual à versão da última migração registrada.
+const CurrentVersion = 2
+
+// ErrNewerVersion indica dados gravados por uma versão mais nova do
+// programa, que não podem ser lidos nem sobrescritos com segurança
+var ErrNewerVersion = errors.New("dados em formato mais novo que o suportado; atualize o programa")
+
+// MigrationStep descreve uma migração aplicada (ou que seria aplicada)
+type MigrationStep struct {
+	Version     int
+	Description string
+	Changes     []string
+}
+
+// MigrationReport resume a atualização dos dados para o formato atual
+type MigrationReport struct {
+	From   int
+	To     int
+	Steps  []MigrationStep
+	Backup string // cópia dos dados originais; vazio se nada foi gravado
+}
+
+// Migrator é implementado por storages capazes de atualizar os dados
+// gravados para o formato atual sob demanda. Com dryRun, apenas informa o
+// que seria feito.
+type Migrator interface {
+	Migrate(dryRun bool) (*MigrationReport, error)
+}
+
+// documentMigration atualiza o documento JSON da versão anterior para
+// version. Migrações trabalham sobre o JSON genérico, e não sobre
+// task.TodoList, para continuarem válidas quando os tipos mudarem.
+// Migrações já publicadas nunca devem ser alteradas.
+type documentMigration struct {
+	version     int
+	description string
+	apply       func(doc map[string]any) (changes []string, err error)
+}
+
+// documentMigrations lista, em ordem, todas as versões do formato JSON
+var documentMigrations = []documentMigration{
+	{
+		version:     1,
+		description: "adiciona a versão do formato e corrige arquivos antigos",
+		apply:       migrateToV1,
+	},
+	{
+		version:     2,
+		description: "posição das tarefas na ordem manual",
+		apply:       migrateToV2,
+	},
+}
+
+// upgradeDocument aplica ao documento as migrações pendentes e retorna o
+// documento atualizado, com o relatório do que mudou
+func upgradeDocument(data []byte) ([]byte, *MigrationReport, error) {
+	var header struct {
+		Version int `json:"version"`
+	}
+	if err := json.Unmarshal(data, &header); err != nil {
+		return nil, nil, err
+	}
+
+	report := &MigrationReport{From: header.Version, To: header.Version}
+	if header.Version > CurrentVersion {
+		return nil, nil, fmt.Errorf("%w (versão %d, suportada %d)", ErrNewerVersion, header.Version, CurrentVersion)
+	}
+	if header.Version == CurrentVersion {
+		return data, report, nil
+	}
+
+	// UseNumber preserva IDs e contadores como inteiros
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var doc map[string]any
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, nil, err
+	}
+
+	for _, m := range documentMigrations {
+		if m.version <= header.Version {
+			continue
+		}
+
+		changes, err := m.apply(doc)
+		if err != nil {
+			return nil, nil, fmt.Errorf("migração para v%d (%s): %w", m.version, m.description, err)
+		}
+		doc["version"] = m.version
+		report.To = m.version
+		report.Steps = append(report.Steps, MigrationStep{
+			Version:     m.version,
+			Description: m.description,
+			Changes:     changes,
+		})
+	}
+
+	migrated, err := json.Marshal(doc)
+	if err != nil {
+		return nil, nil, err
+	}
+	return migrated, report, nil
+}
+
+// migrateToV1 garante a lista de tarefas e um next_id maior que todos os
+// IDs existentes, o que arquivos editados à mão nem sempre respeitam
+func migrateToV1(doc map[string]any) ([]string, error) {
+	var changes []string
+
+	tasks, ok := doc["tasks"].([]any)
+	if !ok {
+		if doc["tasks"] != nil {
+			return nil, fmt.Errorf("campo tasks não é uma lista")
+		}
+		tasks = []any{}
+		doc["tasks"] = tasks
+		changes = append(changes, "lista de tarefas vazia criada")
+	}
+
+	maxID := 0
+	for _, item := range tasks {
+		t, ok := item.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("tarefa inválida: %v", item)
+		}
+		if id, ok := intValue(t["id"]); ok && id > maxID {
+			maxID = id
+		}
+	}
+
+	nextID, _ := intValue(doc["next_id"])
+	if nextID <= maxID {
+		doc["next_id"] = maxID + 1
+		changes = append(changes, fmt.Sprintf("next_id corrigido de %d para %d", nextID, maxID+1))
+	}
+
+	if _, ok := doc["revision"]; !ok {
+		doc["revision"] = 0
+	}
+	return changes, nil
+}
+
+// migrateToV2 dá a cada tarefa, da lista e da lixeira, uma posição na
+// ordem manual ("00001i", "00002i", ...), seguindo os IDs, que refletem a
+// ordem de criação. Tarefas que já têm posição são mantidas, e as novas
+// posições vêm depois da maior delas, para que nenhuma se repita.
+func migrateToV2(doc map[string]any) ([]string, error) {
+	var tasks []map[string]any
+	for _, field := range []string{"tasks", "trash"} {
+		list, _ := doc[field].([]any)
+		for _, item := range list {
+			t, ok := item.(map[string]any)
+			if !ok {
+				return nil, fmt.Errorf("tarefa inválida: %v", item)
+			}
+			tasks = append(tasks, t)
+		}
+	}
+	sort.SliceStable(tasks, func(i, j int) bool {
+		a, _ := intValue(tasks[i]["id"])
+		b, _ := intValue(tasks[j]["id"])
+		return a < b
+	})
+
+	// A parte inteira de uma posição são os 5 primeiros dígitos em base
+	// 36, completados com zeros (veja task.rankAfter)
+	next := int64(1)
+	for _, t := range tasks {
+		if rank, _ := t["rank"].(string); rank != "" {
+			if n, err := strconv.ParseInt((rank + "00000")[:5], 36, 64); err == nil && n >= next {
+				next = n + 1
+			}
+		}
+	}
+
+	ranked := 0
+	for _, t := range tasks {
+		if rank, _ := t["rank"].(string); rank != "" {
+			continue
+		}
+		rank := strconv.FormatInt(next, 36)
+		t["rank"] = strings.Repeat("0", max(0, 5-len(rank))) + rank + "i"
+		next++
+		ranked++
+	}
+
+	if ranked == 0 {
+		return nil, nil
+	}
+	return []string{fmt.Sprintf("%d tarefa(s) receberam uma posição na ordem manual", ranked)}, nil
+}
+
+// intValue converte um número do JSON genérico em int
+func intValue(v any) (int, bool) {
+	switch n := v.(type) {
+	case json.Number:
+		i, err := n.Int64()
+		return int(i), err == nil
+	case float64:
+		return int(n), true
+	case int:
+		return n, true
+	}
+	return 0, false
+}
@@ -0,0 +1,202 @@
+package storage
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// copyFixture copia um arquivo de testdata para um diretório temporário e
+// retorna o caminho da cópia
+func copyFixture(t *testing.T, name string) string {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, data, defaultFileMode); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// stepChanges indexa as alterações do relatório pela versão migrada
+func stepChanges(report *MigrationReport) map[int][]string {
+	changes := make(map[int][]string)
+	for _, step := range report.Steps {
+		changes[step.Version] = step.Changes
+	}
+	return changes
+}
+
+func TestUpgradeDocumentLegacyFile(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("testdata", "v0.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	migrated, report, err := upgradeDocument(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.From != 0 || report.To != CurrentVersion || len(report.Steps) != CurrentVersion {
+		t.Errorf("relatório de v%d para v%d com %d passos", report.From, report.To, len(report.Steps))
+	}
+	if got, want := stepChanges(report)[1], []string{"next_id corrigido de 2 para 4"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("alterações da v1 = %q, esperado %q", got, want)
+	}
+
+	var doc struct {
+		Version  int `json:"version"`
+		Revision int `json:"revision"`
+		NextID   int `json:"next_id"`
+		Tasks    []struct {
+			ID    int    `json:"id"`
+			Title string `json:"title"`
+			Rank  string `json:"rank"`
+		} `json:"tasks"`
+	}
+	if err := json.Unmarshal(migrated, &doc); err != nil {
+		t.Fatal(err)
+	}
+	if doc.Version != CurrentVersion || doc.Revision != 0 || doc.NextID != 4 || len(doc.Tasks) != 2 {
+		t.Errorf("documento migrado: %s", migrated)
+	}
+	for i, want := range []string{"00001i", "00002i"} {
+		if i < len(doc.Tasks) && doc.Tasks[i].Rank != want {
+			t.Errorf("tarefa %d na posição %q, esperado %q", doc.Tasks[i].ID, doc.Tasks[i].Rank, want)
+		}
+	}
+
+	// Um documento já atualizado é devolvido sem alterações
+	again, report, err := upgradeDocument(migrated)
+	if err != nil || !bytes.Equal(again, migrated) || len(report.Steps) > 0 {
+		t.Errorf("segunda migração alterou o documento (%d passos, erro %v)", len(report.Steps), err)
+	}
+}
+
+func TestUpgradeDocumentRepairsMissingTasks(t *testing.T) {
+	migrated, report, err := upgradeDocument([]byte(`{}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"lista de tarefas vazia criada", "next_id corrigido de 0 para 1"}
+	if got := stepChanges(report)[1]; !reflect.DeepEqual(got, want) {
+		t.Errorf("alterações da v1 = %q, esperado %q", got, want)
+	}
+
+	todoList, _, err := decodeDocument(migrated)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if todoList.Tasks == nil || todoList.NextID != 1 {
+		t.Errorf("lista reparada: %+v", todoList)
+	}
+}
+
+func TestUpgradeDocumentRejectsInvalidDocuments(t *testing.T) {
+	for input, reason := range map[string]string{
+		`{"tasks":`:          "JSON incompleto",
+		`{"tasks":{"id":1}}`: "tasks não é uma lista",
+		`{"tasks":[1]}`:      "tarefa que não é objeto",
+	} {
+		if _, _, err := upgradeDocument([]byte(input)); err == nil {
+			t.Errorf("%s aceito: %s", reason, input)
+		}
+	}
+
+	_, _, err := upgradeDocument([]byte(`{"version":99,"tasks":[]}`))
+	if !errors.Is(err, ErrNewerVersion) {
+		t.Errorf("versão mais nova: erro %v, esperado ErrNewerVersion", err)
+	}
+}
+
+func TestDocumentMigrationsEndAtCurrentVersion(t *testing.T) {
+	for i, m := range documentMigrations {
+		if m.version != i+1 {
+			t.Errorf("migração %d tem versão %d", i, m.version)
+		}
+	}
+	if last := documentMigrations[len(documentMigrations)-1].version; last != CurrentVersion {
+		t.Errorf("última migração é v%d, mas CurrentVersion é %d", last, CurrentVersion)
+	}
+}
+
+func TestJSONMigrate(t *testing.T) {
+	path := copyFixture(t, "v0.json")
+	original, _ := os.ReadFile(path)
+	s := NewJSONStorage(path).(*JSONStorage)
+
+	// Load migra apenas em memória
+	todoList, err := s.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if todoList.NextID != 4 {
+		t.Errorf("NextID carregado = %d, esperado 4", todoList.NextID)
+	}
+
+	report, err := s.Migrate(true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if current, _ := os.ReadFile(path); !bytes.Equal(current, original) || report.Backup != "" {
+		t.Error("dry-run alterou o arquivo")
+	}
+	if len(report.Steps) != CurrentVersion {
+		t.Errorf("dry-run listou %d passos, esperado %d", len(report.Steps), CurrentVersion)
+	}
+
+	if report, err = s.Migrate(false); err != nil {
+		t.Fatal(err)
+	}
+	if backup, _ := os.ReadFile(report.Backup); report.Backup != path+".v0.bak" || !bytes.Equal(backup, original) {
+		t.Errorf("backup %q não guarda o arquivo original", report.Backup)
+	}
+	if report, err = s.Migrate(false); err != nil || len(report.Steps) > 0 {
+		t.Errorf("arquivo migrado ainda tem %d passos pendentes (erro %v)", len(report.Steps), err)
+	}
+
+	// O storage que migrou continua podendo salvar sem conflito
+	if err := s.Save(todoList); err != nil {
+		t.Errorf("Save após Migrate: %v", err)
+	}
+}
+
+// Posições já existentes (de um arquivo editado à mão, por exemplo) são
+// mantidas, e as novas vêm depois da maior, sem repetir nenhuma
+func TestMigrateToV2KeepsExistingRanks(t *testing.T) {
+	doc := map[string]any{
+		"tasks": []any{
+			map[string]any{"id": 1.0},
+			map[string]any{"id": 2.0, "rank": "00001i"},
+			map[string]any{"id": 3.0, "rank": "00002ii"},
+		},
+		"trash": []any{
+			map[string]any{"id": 4.0},
+		},
+	}
+	changes, err := migrateToV2(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"2 tarefa(s) receberam uma posição na ordem manual"}; !reflect.DeepEqual(changes, want) {
+		t.Errorf("alterações = %q, esperado %q", changes, want)
+	}
+
+	ranks := make(map[float64]any)
+	for _, field := range []string{"tasks", "trash"} {
+		for _, item := range doc[field].([]any) {
+			ranks[item.(map[string]any)["id"].(float64)] = item.(map[string]any)["rank"]
+		}
+	}
+	want := map[float64]any{1: "00003i", 2: "00001i", 3: "00002ii", 4: "00004i"}
+	if !reflect.DeepEqual(ranks, want) {
+		t.Errorf("posições = %v, esperado %v", ranks, want)
+	}
+}
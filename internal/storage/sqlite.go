@@ -0,0 +1,891 @@
+package storage
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"os"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lucianoZgabriel/go-cli-todo/internal/task"
+
+	// Driver SQLite em Go puro: compila sem cgo
+	_ "modernc.org/sqlite"
+)
+
+// timeLayout é o formato usado para gravar datas no banco
+const timeLayout = time.RFC3339Nano
+
+// SQLiteStorage implementa a interface Storage usando um banco SQLite.
+// Diferente do JSONStorage, Save grava apenas as tarefas que mudaram desde
+// o último Load/Save, e os dados podem ser consultados por outras ferramentas.
+type SQLiteStorage struct {
+	path string
+
+	// revision e base descrevem o banco como estava no último Load/Save.
+	// A revisão gravada na tabela meta aumenta a cada Save.
+	revision int
+	base     *task.TodoList
+
+	// snapshot guarda o estado das tarefas no banco, por ID, para que Save
+	// escreva somente as diferenças
+	snapshot map[int]task.Task
+}
+
+// NewSQLiteStorage cria um novo storage SQLite no arquivo especificado
+func NewSQLiteStorage(path string) Storage {
+	return &SQLiteStorage{
+		path: path,
+	}
+}
+
+// open abre o banco e aplica as migrações pendentes
+func (s *SQLiteStorage) open() (*sql.DB, error) {
+	db, err := s.connect()
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := migrate(db, s.path); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("erro ao migrar banco '%s': %w", s.path, err)
+	}
+	return db, nil
+}
+
+// connect abre o banco sem aplicar migrações
+func (s *SQLiteStorage) connect() (*sql.DB, error) {
+	// _pragma aplica as configurações em cada conexão aberta pelo pool
+	dsn := fmt.Sprintf("file:%s?_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)", s.path)
+	return sql.Open("sqlite", dsn)
+}
+
+// Migrate aplica as migrações de esquema pendentes. Elas já são aplicadas
+// automaticamente ao abrir o banco; com dryRun, apenas lista as pendentes.
+func (s *SQLiteStorage) Migrate(dryRun bool) (*MigrationReport, error) {
+	latest := migrations[len(migrations)-1].version
+	if _, err := os.Stat(s.path); os.IsNotExist(err) {
+		return &MigrationReport{From: latest, To: latest}, nil
+	}
+
+	db, err := s.connect()
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+
+	current, pending, err := pendingMigrations(db)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &MigrationReport{From: current, To: current}
+	for _, m := range pending {
+		report.To = m.version
+		report.Steps = append(report.Steps, MigrationStep{Version: m.version, Description: m.description})
+	}
+	if dryRun || len(pending) == 0 {
+		return report, nil
+	}
+
+	if report.Backup, err = migrate(db, s.path); err != nil {
+		return nil, fmt.Errorf("erro ao migrar banco '%s': %w", s.path, err)
+	}
+	return report, nil
+}
+
+// Save persiste a TodoList no banco SQLite.
+//
+// Se outro processo gravou o banco desde o último Load, nada é salvo e um
+// *ConflictError é retornado, como no JSONStorage.
+func (s *SQLiteStorage) Save(todoList *task.TodoList) error {
+	db, err := s.open()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// Uma escrita logo no início reserva o banco: nenhum outro processo
+	// grava entre a leitura da revisão e o commit
+	_, err = tx.Exec(`INSERT INTO meta (key, value) VALUES ('revision', '0')
+		ON CONFLICT (key) DO NOTHING`)
+	if err != nil {
+		return err
+	}
+	revision, err := loadRevision(tx)
+	if err != nil {
+		return err
+	}
+	if revision != s.revision {
+		theirs, err := loadList(tx)
+		if err != nil {
+			return err
+		}
+		base := s.base
+		if base == nil {
+			base = task.NewTodoList()
+		}
+		s.remember(theirs, revision)
+		return &ConflictError{Base: base, Theirs: theirs}
+	}
+
+	if s.snapshot == nil {
+		if s.snapshot, err = loadSnapshot(tx); err != nil {
+			return err
+		}
+	}
+
+	if err := saveProjects(tx, todoList.Projects); err != nil {
+		return err
+	}
+	if err := saveViews(tx, todoList.Views); err != nil {
+		return err
+	}
+	if err := saveListingSorts(tx, todoList.ListingSorts); err != nil {
+		return err
+	}
+
+	all := allTasks(todoList)
+	current := make(map[int]bool, len(all))
+	for _, t := range all {
+		current[t.ID] = true
+		if old, ok := s.snapshot[t.ID]; ok && reflect.DeepEqual(old, t) {
+			continue
+		}
+		if err := saveTask(tx, t); err != nil {
+			return fmt.Errorf("erro ao salvar tarefa %d: %w", t.ID, err)
+		}
+	}
+
+	for id := range s.snapshot {
+		if current[id] {
+			continue
+		}
+		if _, err := tx.Exec(`DELETE FROM tasks WHERE id = ?`, id); err != nil {
+			return fmt.Errorf("erro ao remover tarefa %d: %w", id, err)
+		}
+	}
+
+	if err := saveMeta(tx, todoList, revision+1); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	s.remember(todoList, revision+1)
+	return nil
+}
+
+// Load carrega uma TodoList do banco SQLite. Se o banco não existir,
+// ele é criado vazio.
+func (s *SQLiteStorage) Load() (*task.TodoList, error) {
+	db, err := s.open()
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+
+	tx, err := db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	todoList, err := loadList(tx)
+	if err != nil {
+		return nil, err
+	}
+	revision, err := loadRevision(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	s.remember(todoList, revision)
+	return todoList, nil
+}
+
+// remember registra a lista como o estado atual do banco, base do próximo
+// Save. A cópia guardada não é afetada por alterações feitas na lista.
+func (s *SQLiteStorage) remember(todoList *task.TodoList, revision int) {
+	all := allTasks(todoList)
+	s.revision = revision
+	s.snapshot = snapshotOf(all)
+
+	s.base = task.NewTodoList()
+	s.base.NextID = todoList.NextID
+	s.base.NextProjectID = todoList.NextProjectID
+	s.base.Projects = slices.Clone(todoList.Projects)
+	s.base.Views = slices.Clone(todoList.Views)
+	s.base.ListingSorts = maps.Clone(todoList.ListingSorts)
+	for _, t := range all {
+		if t.IsDeleted() {
+			s.base.Trash = append(s.base.Trash, copyTask(t))
+		} else {
+			s.base.Tasks = append(s.base.Tasks, copyTask(t))
+		}
+	}
+}
+
+// loadList lê a lista completa: contadores, projetos, visões, ordenações
+// padrão e tarefas, separando as da lixeira
+func loadList(tx *sql.Tx) (*task.TodoList, error) {
+	todoList := task.NewTodoList()
+	if err := loadMeta(tx, todoList); err != nil {
+		return nil, err
+	}
+	var err error
+	if todoList.Projects, err = loadProjects(tx); err != nil {
+		return nil, err
+	}
+	if todoList.Views, err = loadViews(tx); err != nil {
+		return nil, err
+	}
+	if todoList.ListingSorts, err = loadListingSorts(tx); err != nil {
+		return nil, err
+	}
+	all, err := loadTasks(tx)
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range all {
+		if t.IsDeleted() {
+			todoList.Trash = append(todoList.Trash, t)
+		} else {
+			todoList.Tasks = append(todoList.Tasks, t)
+		}
+	}
+	return todoList, nil
+}
+
+// allTasks retorna as tarefas da lista e da lixeira, que compartilham a
+// tabela tasks
+func allTasks(todoList *task.TodoList) []task.Task {
+	all := make([]task.Task, 0, len(todoList.Tasks)+len(todoList.Trash))
+	all = append(all, todoList.Tasks...)
+	return append(all, todoList.Trash...)
+}
+
+// SaveHistory grava o histórico de desfazer/refazer no banco
+func (s *SQLiteStorage) SaveHistory(history *task.History) error {
+	data, err := json.Marshal(history)
+	if err != nil {
+		return err
+	}
+
+	db, err := s.open()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	_, err = db.Exec(`INSERT INTO history (id, data) VALUES (1, ?)
+		ON CONFLICT (id) DO UPDATE SET data = excluded.data`, string(data))
+	return err
+}
+
+// LoadHistory lê o histórico de desfazer/refazer do banco
+func (s *SQLiteStorage) LoadHistory() (*task.History, error) {
+	db, err := s.open()
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+
+	history := task.NewHistory(task.DefaultHistoryDepth)
+	var data string
+	err = db.QueryRow(`SELECT data FROM history WHERE id = 1`).Scan(&data)
+	if err == sql.ErrNoRows {
+		return history, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal([]byte(data), history); err != nil {
+		return nil, fmt.Errorf("erro ao decodificar histórico: %w", err)
+	}
+	return history, nil
+}
+
+// snapshotOf indexa uma cópia das tarefas por ID. A cópia não compartilha
+// slices nem ponteiros com a lista, que pode ser alterada no lugar (tags,
+// prazo, dependências...) depois do Save.
+func snapshotOf(tasks []task.Task) map[int]task.Task {
+	snapshot := make(map[int]task.Task, len(tasks))
+	for _, t := range tasks {
+		snapshot[t.ID] = copyTask(t)
+	}
+	return snapshot
+}
+
+// copyTask copia a tarefa em profundidade. Slices nil continuam nil, para
+// que a comparação com reflect.DeepEqual no Save não veja diferenças.
+func copyTask(t task.Task) task.Task {
+	t.DueDate = copyTime(t.DueDate)
+	t.UpdatedAt = copyTime(t.UpdatedAt)
+	t.CompletedAt = copyTime(t.CompletedAt)
+	t.DeletedAt = copyTime(t.DeletedAt)
+	t.Tags = slices.Clone(t.Tags)
+	t.BlockedBy = slices.Clone(t.BlockedBy)
+	if t.Recurrence != nil {
+		recurrence := *t.Recurrence
+		recurrence.Weekdays = slices.Clone(recurrence.Weekdays)
+		t.Recurrence = &recurrence
+	}
+	t.Events = slices.Clone(t.Events)
+	for i := range t.Events {
+		t.Events[i].Changes = slices.Clone(t.Events[i].Changes)
+	}
+	return t
+}
+
+// copyTime copia um horário opcional
+func copyTime(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	copied := *t
+	return &copied
+}
+
+// loadSnapshot lê as tarefas atualmente gravadas no banco
+func loadSnapshot(tx *sql.Tx) (map[int]task.Task, error) {
+	tasks, err := loadTasks(tx)
+	if err != nil {
+		return nil, err
+	}
+	return snapshotOf(tasks), nil
+}
+
+// saveMeta grava os contadores da lista e a nova revisão. Os contadores
+// nunca ficam abaixo dos IDs já gravados no banco.
+func saveMeta(tx *sql.Tx, todoList *task.TodoList, revision int) error {
+	var maxID, maxProjectID int
+	if err := tx.QueryRow(`SELECT COALESCE(MAX(id), 0) FROM tasks`).Scan(&maxID); err != nil {
+		return err
+	}
+	if err := tx.QueryRow(`SELECT COALESCE(MAX(id), 0) FROM projects`).Scan(&maxProjectID); err != nil {
+		return err
+	}
+
+	values := map[string]int{
+		"next_id":         todoList.NextID,
+		"next_project_id": todoList.NextProjectID,
+		"revision":        revision,
+	}
+	if maxID > 0 && maxID >= values["next_id"] {
+		values["next_id"] = maxID + 1
+	}
+	if maxProjectID > 0 && maxProjectID >= values["next_project_id"] {
+		values["next_project_id"] = maxProjectID + 1
+	}
+	for key, value := range values {
+		_, err := tx.Exec(`INSERT INTO meta (key, value) VALUES (?, ?)
+			ON CONFLICT (key) DO UPDATE SET value = excluded.value`, key, strconv.Itoa(value))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// loadRevision lê a revisão do banco; bancos sem revisão estão na zero
+func loadRevision(tx *sql.Tx) (int, error) {
+	var value string
+	err := tx.QueryRow(`SELECT value FROM meta WHERE key = 'revision'`).Scan(&value)
+	if err == sql.ErrNoRows {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(value)
+}
+
+// loadMeta lê os contadores da lista
+func loadMeta(tx *sql.Tx, todoList *task.TodoList) error {
+	rows, err := tx.Query(`SELECT key, value FROM meta`)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var key, value string
+		if err := rows.Scan(&key, &value); err != nil {
+			return err
+		}
+
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			continue
+		}
+		switch key {
+		case "next_id":
+			todoList.NextID = n
+		case "next_project_id":
+			todoList.NextProjectID = n
+		}
+	}
+	return rows.Err()
+}
+
+// saveProjects grava os projetos que diferem dos gravados e remove os que
+// não estão mais na lista
+func saveProjects(tx *sql.Tx, projects []task.Project) error {
+	saved, err := loadProjects(tx)
+	if err != nil {
+		return err
+	}
+	stored := make(map[int]task.Project, len(saved))
+	for _, p := range saved {
+		stored[p.ID] = p
+	}
+
+	current := make(map[int]bool, len(projects))
+	for _, p := range projects {
+		current[p.ID] = true
+		if old, ok := stored[p.ID]; ok && old.Name == p.Name && old.Archived == p.Archived &&
+			old.CreatedAt.Equal(p.CreatedAt) {
+			continue
+		}
+		_, err := tx.Exec(`INSERT INTO projects (id, name, archived, created_at) VALUES (?, ?, ?, ?)
+			ON CONFLICT (id) DO UPDATE SET
+				name = excluded.name,
+				archived = excluded.archived,
+				created_at = excluded.created_at`,
+			p.ID, p.Name, p.Archived, p.CreatedAt.Format(timeLayout))
+		if err != nil {
+			return fmt.Errorf("erro ao salvar projeto '%s': %w", p.Name, err)
+		}
+	}
+
+	for id := range stored {
+		if current[id] {
+			continue
+		}
+		if _, err := tx.Exec(`DELETE FROM projects WHERE id = ?`, id); err != nil {
+			return fmt.Errorf("erro ao remover projeto %d: %w", id, err)
+		}
+	}
+	return nil
+}
+
+// loadProjects lê os projetos gravados
+func loadProjects(tx *sql.Tx) ([]task.Project, error) {
+	rows, err := tx.Query(`SELECT id, name, archived, created_at FROM projects ORDER BY id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var projects []task.Project
+	for rows.Next() {
+		var p task.Project
+		var createdAt string
+		if err := rows.Scan(&p.ID, &p.Name, &p.Archived, &createdAt); err != nil {
+			return nil, err
+		}
+		if p.CreatedAt, err = time.Parse(timeLayout, createdAt); err != nil {
+			return nil, err
+		}
+		projects = append(projects, p)
+	}
+	return projects, rows.Err()
+}
+
+// saveViews grava as visões que diferem das gravadas e remove as que não
+// estão mais na lista. As remoções vêm antes, para que uma visão recriada
+// com o mesmo nome em outra caixa não colida com a antiga.
+func saveViews(tx *sql.Tx, views []task.View) error {
+	saved, err := loadViews(tx)
+	if err != nil {
+		return err
+	}
+	current := make(map[string]task.View, len(views))
+	for _, v := range views {
+		current[v.Name] = v
+	}
+
+	stored := make(map[string]task.View, len(saved))
+	for _, v := range saved {
+		if _, ok := current[v.Name]; ok {
+			stored[v.Name] = v
+			continue
+		}
+		if _, err := tx.Exec(`DELETE FROM views WHERE name = ?`, v.Name); err != nil {
+			return fmt.Errorf("erro ao remover visão '%s': %w", v.Name, err)
+		}
+	}
+
+	for _, v := range views {
+		if old, ok := stored[v.Name]; ok && old.Query == v.Query && old.Sort == v.Sort &&
+			old.Pinned == v.Pinned && old.CreatedAt.Equal(v.CreatedAt) {
+			continue
+		}
+		_, err := tx.Exec(`INSERT INTO views (name, query, sort, pinned, created_at) VALUES (?, ?, ?, ?, ?)
+			ON CONFLICT (name) DO UPDATE SET
+				name = excluded.name,
+				query = excluded.query,
+				sort = excluded.sort,
+				pinned = excluded.pinned,
+				created_at = excluded.created_at`,
+			v.Name, v.Query, v.Sort, v.Pinned, v.CreatedAt.Format(timeLayout))
+		if err != nil {
+			return fmt.Errorf("erro ao salvar visão '%s': %w", v.Name, err)
+		}
+	}
+	return nil
+}
+
+// loadViews lê as visões gravadas, na ordem em que foram criadas
+func loadViews(tx *sql.Tx) ([]task.View, error) {
+	rows, err := tx.Query(`SELECT name, query, sort, pinned, created_at FROM views ORDER BY created_at, name`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var views []task.View
+	for rows.Next() {
+		var v task.View
+		var createdAt string
+		if err := rows.Scan(&v.Name, &v.Query, &v.Sort, &v.Pinned, &createdAt); err != nil {
+			return nil, err
+		}
+		if v.CreatedAt, err = time.Parse(timeLayout, createdAt); err != nil {
+			return nil, err
+		}
+		views = append(views, v)
+	}
+	return views, rows.Err()
+}
+
+// saveListingSorts grava as ordenações padrão que diferem das gravadas e
+// remove as das listagens que voltaram à ordem padrão
+func saveListingSorts(tx *sql.Tx, sorts map[string]string) error {
+	stored, err := loadListingSorts(tx)
+	if err != nil {
+		return err
+	}
+
+	for listing := range stored {
+		if _, ok := sorts[listing]; ok {
+			continue
+		}
+		if _, err := tx.Exec(`DELETE FROM listing_sorts WHERE listing = ?`, listing); err != nil {
+			return fmt.Errorf("erro ao remover ordenação de '%s': %w", listing, err)
+		}
+	}
+
+	for listing, spec := range sorts {
+		if old, ok := stored[listing]; ok && old == spec {
+			continue
+		}
+		_, err := tx.Exec(`INSERT INTO listing_sorts (listing, sort) VALUES (?, ?)
+			ON CONFLICT (listing) DO UPDATE SET sort = excluded.sort`, listing, spec)
+		if err != nil {
+			return fmt.Errorf("erro ao salvar ordenação de '%s': %w", listing, err)
+		}
+	}
+	return nil
+}
+
+// loadListingSorts lê as ordenações padrão gravadas; nil se não houver
+func loadListingSorts(tx *sql.Tx) (map[string]string, error) {
+	rows, err := tx.Query(`SELECT listing, sort FROM listing_sorts`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var sorts map[string]string
+	for rows.Next() {
+		var listing, spec string
+		if err := rows.Scan(&listing, &spec); err != nil {
+			return nil, err
+		}
+		if sorts == nil {
+			sorts = make(map[string]string)
+		}
+		sorts[listing] = spec
+	}
+	return sorts, rows.Err()
+}
+
+// saveTask insere ou atualiza uma tarefa, suas tags e dependências
+func saveTask(tx *sql.Tx, t task.Task) error {
+	var recurrence sql.NullString
+	if t.Recurrence != nil {
+		recurrence = sql.NullString{String: t.Recurrence.String(), Valid: true}
+	}
+
+	_, err := tx.Exec(`INSERT INTO tasks (
+			id, title, description, completed, priority, due_date, due_has_time,
+			project_id, parent_id, recurrence, series_id, next_occurrence_id, rank,
+			created_at, updated_at, completed_at, deleted_at
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (id) DO UPDATE SET
+			title = excluded.title,
+			description = excluded.description,
+			completed = excluded.completed,
+			priority = excluded.priority,
+			due_date = excluded.due_date,
+			due_has_time = excluded.due_has_time,
+			project_id = excluded.project_id,
+			parent_id = excluded.parent_id,
+			recurrence = excluded.recurrence,
+			series_id = excluded.series_id,
+			next_occurrence_id = excluded.next_occurrence_id,
+			rank = excluded.rank,
+			created_at = excluded.created_at,
+			updated_at = excluded.updated_at,
+			completed_at = excluded.completed_at,
+			deleted_at = excluded.deleted_at`,
+		t.ID, t.Title, t.Description, t.Completed, t.Priority.String(),
+		nullTime(t.DueDate), t.DueHasTime, t.ProjectID, nullInt(t.ParentID),
+		recurrence, nullInt(t.SeriesID), nullInt(t.NextOccurrenceID), t.Rank,
+		t.CreatedAt.Format(timeLayout), nullTime(t.UpdatedAt), nullTime(t.CompletedAt),
+		nullTime(t.DeletedAt))
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(`DELETE FROM task_tags WHERE task_id = ?`, t.ID); err != nil {
+		return err
+	}
+	for _, tag := range t.Tags {
+		if _, err := tx.Exec(`INSERT INTO task_tags (task_id, tag) VALUES (?, ?)`, t.ID, tag); err != nil {
+			return err
+		}
+	}
+
+	if _, err := tx.Exec(`DELETE FROM task_dependencies WHERE task_id = ?`, t.ID); err != nil {
+		return err
+	}
+	for _, blockerID := range t.BlockedBy {
+		_, err := tx.Exec(`INSERT INTO task_dependencies (task_id, blocker_id) VALUES (?, ?)`, t.ID, blockerID)
+		if err != nil {
+			return err
+		}
+	}
+	return saveEvents(tx, t)
+}
+
+// saveEvents acrescenta os eventos de auditoria ainda não gravados. Como o
+// histórico só cresce, os eventos já presentes no banco não são reescritos.
+func saveEvents(tx *sql.Tx, t task.Task) error {
+	var saved int
+	if err := tx.QueryRow(`SELECT COUNT(*) FROM task_events WHERE task_id = ?`, t.ID).Scan(&saved); err != nil {
+		return err
+	}
+	if saved > len(t.Events) {
+		// a tarefa voltou a uma versão anterior (ex.: merge): regrava tudo
+		if _, err := tx.Exec(`DELETE FROM task_events WHERE task_id = ?`, t.ID); err != nil {
+			return err
+		}
+		saved = 0
+	}
+
+	for seq := saved; seq < len(t.Events); seq++ {
+		event := t.Events[seq]
+		var changes sql.NullString
+		if len(event.Changes) > 0 {
+			data, err := json.Marshal(event.Changes)
+			if err != nil {
+				return err
+			}
+			changes = sql.NullString{String: string(data), Valid: true}
+		}
+
+		_, err := tx.Exec(`INSERT INTO task_events (task_id, seq, at, kind, changes) VALUES (?, ?, ?, ?, ?)`,
+			t.ID, seq, event.At.Format(timeLayout), string(event.Kind), changes)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// loadTasks lê todas as tarefas, inclusive as da lixeira, com tags,
+// dependências e histórico, na ordem de criação
+func loadTasks(tx *sql.Tx) ([]task.Task, error) {
+	rows, err := tx.Query(`SELECT
+			id, title, description, completed, priority, due_date, due_has_time,
+			project_id, parent_id, recurrence, series_id, next_occurrence_id, rank,
+			created_at, updated_at, completed_at, deleted_at
+		FROM tasks ORDER BY id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tasks := make([]task.Task, 0)
+	index := make(map[int]int)
+	for rows.Next() {
+		var t task.Task
+		var priority, createdAt string
+		var dueDate, updatedAt, completedAt, deletedAt, recurrence sql.NullString
+		var parentID, seriesID, nextOccurrenceID sql.NullInt64
+
+		err := rows.Scan(&t.ID, &t.Title, &t.Description, &t.Completed, &priority,
+			&dueDate, &t.DueHasTime, &t.ProjectID, &parentID, &recurrence,
+			&seriesID, &nextOccurrenceID, &t.Rank, &createdAt, &updatedAt, &completedAt, &deletedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		if t.Priority, err = task.ParsePriority(priority); err != nil {
+			return nil, err
+		}
+		if t.CreatedAt, err = time.Parse(timeLayout, createdAt); err != nil {
+			return nil, err
+		}
+		if t.DueDate, err = parseNullTime(dueDate); err != nil {
+			return nil, err
+		}
+		if t.UpdatedAt, err = parseNullTime(updatedAt); err != nil {
+			return nil, err
+		}
+		if t.CompletedAt, err = parseNullTime(completedAt); err != nil {
+			return nil, err
+		}
+		if t.DeletedAt, err = parseNullTime(deletedAt); err != nil {
+			return nil, err
+		}
+		if recurrence.Valid {
+			if t.Recurrence, err = task.ParseRecurrence(recurrence.String); err != nil {
+				return nil, err
+			}
+		}
+		t.ParentID = int(parentID.Int64)
+		t.SeriesID = int(seriesID.Int64)
+		t.NextOccurrenceID = int(nextOccurrenceID.Int64)
+
+		index[t.ID] = len(tasks)
+		tasks = append(tasks, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := loadTags(tx, tasks, index); err != nil {
+		return nil, err
+	}
+	if err := loadDependencies(tx, tasks, index); err != nil {
+		return nil, err
+	}
+	if err := loadEvents(tx, tasks, index); err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+// loadTags preenche as tags das tarefas
+func loadTags(tx *sql.Tx, tasks []task.Task, index map[int]int) error {
+	rows, err := tx.Query(`SELECT task_id, tag FROM task_tags ORDER BY task_id, tag`)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var taskID int
+		var tag string
+		if err := rows.Scan(&taskID, &tag); err != nil {
+			return err
+		}
+		if i, ok := index[taskID]; ok {
+			tasks[i].Tags = append(tasks[i].Tags, tag)
+		}
+	}
+	return rows.Err()
+}
+
+// loadDependencies preenche as dependências das tarefas
+func loadDependencies(tx *sql.Tx, tasks []task.Task, index map[int]int) error {
+	rows, err := tx.Query(`SELECT task_id, blocker_id FROM task_dependencies ORDER BY task_id, blocker_id`)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var taskID, blockerID int
+		if err := rows.Scan(&taskID, &blockerID); err != nil {
+			return err
+		}
+		if i, ok := index[taskID]; ok {
+			tasks[i].BlockedBy = append(tasks[i].BlockedBy, blockerID)
+		}
+	}
+	return rows.Err()
+}
+
+// loadEvents preenche o histórico de auditoria das tarefas
+func loadEvents(tx *sql.Tx, tasks []task.Task, index map[int]int) error {
+	rows, err := tx.Query(`SELECT task_id, at, kind, changes FROM task_events ORDER BY task_id, seq`)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var taskID int
+		var at, kind string
+		var changes sql.NullString
+		if err := rows.Scan(&taskID, &at, &kind, &changes); err != nil {
+			return err
+		}
+
+		event := task.Event{Kind: task.EventKind(kind)}
+		if event.At, err = time.Parse(timeLayout, at); err != nil {
+			return err
+		}
+		if changes.Valid {
+			if err := json.Unmarshal([]byte(changes.String), &event.Changes); err != nil {
+				return err
+			}
+		}
+		if i, ok := index[taskID]; ok {
+			tasks[i].Events = append(tasks[i].Events, event)
+		}
+	}
+	return rows.Err()
+}
+
+// nullTime converte um ponteiro de data em valor gravável (NULL se nil)
+func nullTime(t *time.Time) sql.NullString {
+	if t == nil {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: t.Format(timeLayout), Valid: true}
+}
+
+// parseNullTime converte um valor lido do banco em ponteiro de data
+func parseNullTime(value sql.NullString) (*time.Time, error) {
+	if !value.Valid || strings.TrimSpace(value.String) == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(timeLayout, value.String)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// nullInt grava zero como NULL, usado em referências opcionais entre tarefas
+func nullInt(n int) sql.NullInt64 {
+	return sql.NullInt64{Int64: int64(n), Valid: n != 0}
+}
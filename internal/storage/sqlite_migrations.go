@@ -0,0 +1,236 @@
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+)
+
+// migration é um passo de evolução do esquema do banco SQLite. Migrações
+// já aplicadas nunca devem ser alteradas: mudanças no esquema entram como
+// uma nova versão no fim da lista.
+type migration struct {
+	version     int
+	description string
+	statements  []string
+}
+
+// migrations lista, em ordem, todas as versões do esquema
+var migrations = []migration{
+	{
+		version:     1,
+		description: "esquema inicial",
+		statements: []string{
+			`CREATE TABLE meta (
+				key   TEXT PRIMARY KEY,
+				value TEXT NOT NULL
+			)`,
+			`CREATE TABLE projects (
+				id         INTEGER PRIMARY KEY,
+				name       TEXT NOT NULL,
+				archived   BOOLEAN NOT NULL DEFAULT 0,
+				created_at TEXT NOT NULL
+			)`,
+			`CREATE TABLE tasks (
+				id                 INTEGER PRIMARY KEY,
+				title              TEXT NOT NULL,
+				description        TEXT NOT NULL DEFAULT '',
+				completed          BOOLEAN NOT NULL DEFAULT 0,
+				priority           TEXT NOT NULL DEFAULT 'none',
+				due_date           TEXT,
+				due_has_time       BOOLEAN NOT NULL DEFAULT 0,
+				project_id         INTEGER NOT NULL DEFAULT 0,
+				parent_id          INTEGER,
+				recurrence         TEXT,
+				series_id          INTEGER,
+				next_occurrence_id INTEGER,
+				created_at         TEXT NOT NULL,
+				completed_at       TEXT
+			)`,
+			`CREATE TABLE task_tags (
+				task_id INTEGER NOT NULL REFERENCES tasks (id) ON DELETE CASCADE,
+				tag     TEXT NOT NULL,
+				PRIMARY KEY (task_id, tag)
+			)`,
+			`CREATE TABLE task_dependencies (
+				task_id    INTEGER NOT NULL REFERENCES tasks (id) ON DELETE CASCADE,
+				blocker_id INTEGER NOT NULL,
+				PRIMARY KEY (task_id, blocker_id)
+			)`,
+			`CREATE INDEX idx_tasks_project ON tasks (project_id)`,
+			`CREATE INDEX idx_tasks_parent ON tasks (parent_id)`,
+			`CREATE INDEX idx_task_tags_tag ON task_tags (tag)`,
+		},
+	},
+	{
+		version:     2,
+		description: "histórico de desfazer/refazer",
+		statements: []string{
+			`CREATE TABLE history (
+				id   INTEGER PRIMARY KEY CHECK (id = 1),
+				data TEXT NOT NULL
+			)`,
+		},
+	},
+	{
+		version:     3,
+		description: "lixeira de tarefas removidas",
+		statements: []string{
+			`ALTER TABLE tasks ADD COLUMN deleted_at TEXT`,
+			`CREATE INDEX idx_tasks_deleted ON tasks (deleted_at)`,
+		},
+	},
+	{
+		version:     4,
+		description: "histórico de auditoria das tarefas",
+		statements: []string{
+			`ALTER TABLE tasks ADD COLUMN updated_at TEXT`,
+			`CREATE TABLE task_events (
+				task_id INTEGER NOT NULL REFERENCES tasks (id) ON DELETE CASCADE,
+				seq     INTEGER NOT NULL,
+				at      TEXT NOT NULL,
+				kind    TEXT NOT NULL,
+				changes TEXT,
+				PRIMARY KEY (task_id, seq)
+			)`,
+		},
+	},
+	{
+		version:     5,
+		description: "visões salvas",
+		statements: []string{
+			`CREATE TABLE views (
+				name       TEXT PRIMARY KEY COLLATE NOCASE,
+				query      TEXT NOT NULL,
+				pinned     BOOLEAN NOT NULL DEFAULT 0,
+				created_at TEXT NOT NULL
+			)`,
+		},
+	},
+	{
+		version:     6,
+		description: "ordenação padrão das visões",
+		statements: []string{
+			`ALTER TABLE views ADD COLUMN sort TEXT NOT NULL DEFAULT ''`,
+		},
+	},
+	{
+		version:     7,
+		description: "posição das tarefas na ordem manual",
+		statements: []string{
+			`ALTER TABLE tasks ADD COLUMN rank TEXT NOT NULL DEFAULT ''`,
+			// Posições "00001i", "00002i", ... (base 36) na ordem dos IDs
+			`UPDATE tasks SET rank = ordered.rank
+			FROM (
+				SELECT id,
+					substr('0123456789abcdefghijklmnopqrstuvwxyz', n / 1679616 % 36 + 1, 1) ||
+					substr('0123456789abcdefghijklmnopqrstuvwxyz', n / 46656 % 36 + 1, 1) ||
+					substr('0123456789abcdefghijklmnopqrstuvwxyz', n / 1296 % 36 + 1, 1) ||
+					substr('0123456789abcdefghijklmnopqrstuvwxyz', n / 36 % 36 + 1, 1) ||
+					substr('0123456789abcdefghijklmnopqrstuvwxyz', n % 36 + 1, 1) || 'i' AS rank
+				FROM (SELECT id, ROW_NUMBER() OVER (ORDER BY id) AS n FROM tasks)
+			) AS ordered
+			WHERE ordered.id = tasks.id`,
+		},
+	},
+	{
+		version:     8,
+		description: "ordenação padrão das listagens",
+		statements: []string{
+			`CREATE TABLE listing_sorts (
+				listing TEXT PRIMARY KEY,
+				sort    TEXT NOT NULL
+			)`,
+		},
+	},
+}
+
+// migrate cria a tabela de controle e aplica, cada uma em sua transação,
+// as migrações ainda não registradas no banco. Um banco que já tinha
+// dados é copiado antes para "<arquivo>.v<versão>.bak", cujo caminho é
+// retornado.
+func migrate(db *sql.DB, path string) (backup string, err error) {
+	_, err = db.Exec(`CREATE TABLE IF NOT EXISTS schema_migrations (
+		version    INTEGER PRIMARY KEY,
+		applied_at TEXT NOT NULL DEFAULT CURRENT_TIMESTAMP
+	)`)
+	if err != nil {
+		return "", err
+	}
+
+	current, pending, err := pendingMigrations(db)
+	if err != nil || len(pending) == 0 {
+		return "", err
+	}
+
+	if current > 0 {
+		backup = fmt.Sprintf("%s.v%d%s", path, current, backupSuffix)
+		if _, err := os.Stat(backup); os.IsNotExist(err) {
+			if _, err := db.Exec(`VACUUM INTO ?`, backup); err != nil {
+				return "", fmt.Errorf("erro ao criar backup: %w", err)
+			}
+		}
+	}
+
+	for _, m := range pending {
+		if err := applyMigration(db, m); err != nil {
+			return "", fmt.Errorf("migração %d (%s): %w", m.version, m.description, err)
+		}
+	}
+	return backup, nil
+}
+
+// pendingMigrations retorna a versão atual do banco e as migrações ainda
+// não aplicadas, sem alterar nada
+func pendingMigrations(db *sql.DB) (int, []migration, error) {
+	current, err := schemaVersion(db)
+	if err != nil {
+		return 0, nil, err
+	}
+	if latest := migrations[len(migrations)-1].version; current > latest {
+		return 0, nil, fmt.Errorf("%w (versão %d, suportada %d)", ErrNewerVersion, current, latest)
+	}
+
+	var pending []migration
+	for _, m := range migrations {
+		if m.version > current {
+			pending = append(pending, m)
+		}
+	}
+	return current, pending, nil
+}
+
+// schemaVersion retorna a última versão aplicada (zero para banco novo)
+func schemaVersion(db *sql.DB) (int, error) {
+	var tables int
+	err := db.QueryRow(`SELECT COUNT(*) FROM sqlite_master
+		WHERE type = 'table' AND name = 'schema_migrations'`).Scan(&tables)
+	if err != nil || tables == 0 {
+		return 0, err
+	}
+
+	var version sql.NullInt64
+	if err := db.QueryRow(`SELECT MAX(version) FROM schema_migrations`).Scan(&version); err != nil {
+		return 0, err
+	}
+	return int(version.Int64), nil
+}
+
+// applyMigration executa uma migração e a registra atomicamente
+func applyMigration(db *sql.DB, m migration) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, statement := range m.statements {
+		if _, err := tx.Exec(statement); err != nil {
+			return err
+		}
+	}
+	if _, err := tx.Exec(`INSERT INTO schema_migrations (version) VALUES (?)`, m.version); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
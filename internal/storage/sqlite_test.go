@@ -0,0 +1,342 @@
+package storage
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/lucianoZgabriel/go-cli-todo/internal/task"
+)
+
+// tempDB retorna o caminho de um banco novo em um diretório temporário
+func tempDB(t *testing.T) string {
+	t.Helper()
+	return filepath.Join(t.TempDir(), "tasks.db")
+}
+
+// reload lê o banco com um storage novo, sem o snapshot do anterior
+func reload(t *testing.T, path string) *task.TodoList {
+	t.Helper()
+	todoList, err := NewSQLiteStorage(path).Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	return todoList
+}
+
+// assertSameList compara as listas como seriam gravadas em JSON: o banco
+// guarda as datas em texto, sem o relógio monotônico de time.Now
+func assertSameList(t *testing.T, got, want *task.TodoList) {
+	t.Helper()
+	gotJSON, _ := json.MarshalIndent(got, "", " ")
+	wantJSON, _ := json.MarshalIndent(want, "", " ")
+	if string(gotJSON) != string(wantJSON) {
+		t.Errorf("lista relida difere da gravada\nrelida:  %s\ngravada: %s", gotJSON, wantJSON)
+	}
+}
+
+func TestSQLiteRoundTrip(t *testing.T) {
+	path := tempDB(t)
+	tl := task.NewTodoList()
+	project, err := tl.AddProject("trabalho")
+	if err != nil {
+		t.Fatal(err)
+	}
+	deploy := tl.AddTask("Deploy da API #infra #urgente", "janela das 22h")
+	deploy.Priority = task.PriorityHigh
+	deploy.ProjectID = project.ID
+	due := time.Date(2026, time.November, 3, 22, 0, 0, 0, time.Local)
+	deploy.DueDate, deploy.DueHasTime = &due, true
+	if deploy.Recurrence, err = task.ParseRecurrence("weekly:mon,fri"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tl.AddSubtask(deploy.ID, "Avisar o suporte", ""); err != nil {
+		t.Fatal(err)
+	}
+	backup := tl.AddTask("Backup do banco", "")
+	if err := tl.AddDependency(deploy.ID, backup.ID); err != nil {
+		t.Fatal(err)
+	}
+	if err := tl.ToggleTask(backup.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := NewSQLiteStorage(path).Save(tl); err != nil {
+		t.Fatal(err)
+	}
+	assertSameList(t, reload(t, path), tl)
+}
+
+// O Save só grava as tarefas que diferem do snapshot. Alterações feitas no
+// lugar, sem trocar o slice ou o ponteiro da tarefa, também são diferenças.
+func TestSQLiteSaveSeesInPlaceChanges(t *testing.T) {
+	path := tempDB(t)
+	s := NewSQLiteStorage(path)
+
+	tl := task.NewTodoList()
+	tl.AddTask("bloqueadora", "")
+	tl.AddTask("outra bloqueadora", "")
+	tl.AddTask("tarefa #infra", "")
+	target := &tl.Tasks[2]
+	due := time.Date(2026, time.October, 20, 0, 0, 0, 0, time.Local)
+	target.DueDate = &due
+	target.Recurrence = &task.Recurrence{Every: 1, Unit: task.UnitWeek}
+	target.BlockedBy = []int{1}
+	if err := s.Save(tl); err != nil {
+		t.Fatal(err)
+	}
+
+	target.Tags[0] = "casa"
+	*target.DueDate = due.AddDate(0, 0, 7)
+	target.Recurrence.Every = 2
+	target.BlockedBy[0] = 2
+	if err := s.Save(tl); err != nil {
+		t.Fatal(err)
+	}
+	assertSameList(t, reload(t, path), tl)
+}
+
+func TestSQLiteSaveDeletesRemovedTasks(t *testing.T) {
+	path := tempDB(t)
+	s := NewSQLiteStorage(path)
+
+	tl := task.NewTodoList()
+	tl.AddTask("fica", "")
+	tl.AddTask("sai #infra", "")
+	if err := s.Save(tl); err != nil {
+		t.Fatal(err)
+	}
+	if err := tl.RemoveTask(2); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Save(tl); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded := reload(t, path)
+	if len(loaded.Tasks) != 1 || loaded.Tasks[0].Title != "fica" {
+		t.Errorf("tarefas após remover: %v", loaded.Tasks)
+	}
+}
+
+func TestSQLiteMigrationsRecordEachVersion(t *testing.T) {
+	path := tempDB(t)
+	reload(t, path) // cria o banco
+	reload(t, path) // reabrir não aplica nada de novo
+
+	db, err := NewSQLiteStorage(path).(*SQLiteStorage).open()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	rows, err := db.Query(`SELECT version FROM schema_migrations ORDER BY version`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+	var applied []int
+	for rows.Next() {
+		var version int
+		if err := rows.Scan(&version); err != nil {
+			t.Fatal(err)
+		}
+		applied = append(applied, version)
+	}
+	if len(applied) != len(migrations) {
+		t.Fatalf("versões registradas %v, esperado %d migrações", applied, len(migrations))
+	}
+	for i, m := range migrations {
+		if m.version != i+1 || applied[i] != m.version {
+			t.Errorf("migração %d: versão %d, registrada %d", i, m.version, applied[i])
+		}
+	}
+}
+
+func TestSQLiteRefusesNewerSchema(t *testing.T) {
+	path := tempDB(t)
+	reload(t, path)
+
+	db, err := NewSQLiteStorage(path).(*SQLiteStorage).open()
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = db.Exec(`INSERT INTO schema_migrations (version) VALUES (?)`, len(migrations)+1)
+	db.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := NewSQLiteStorage(path).Load(); err == nil {
+		t.Error("banco de uma versão mais nova foi aberto")
+	}
+}
+
+// Um banco na versão 6, anterior à ordem manual, ganha posições na ordem
+// dos IDs, inclusive para a lixeira, e é copiado antes da migração
+func TestSQLiteRankMigration(t *testing.T) {
+	path := tempDB(t)
+	s := NewSQLiteStorage(path).(*SQLiteStorage)
+	db, err := s.connect()
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = db.Exec(`CREATE TABLE schema_migrations (version INTEGER PRIMARY KEY, applied_at TEXT)`)
+	for _, m := range migrations[:6] {
+		if err == nil {
+			err = applyMigration(db, m)
+		}
+	}
+	if err == nil {
+		created := time.Now().Format(timeLayout)
+		_, err = db.Exec(`INSERT INTO tasks (id, title, created_at, deleted_at) VALUES
+			(5, 'cinco', ?, NULL), (2, 'dois', ?, NULL), (3, 'três', ?, ?)`,
+			created, created, created, created)
+	}
+	db.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	todoList := reload(t, path)
+	ranks := make(map[int]string)
+	for _, list := range [][]task.Task{todoList.Tasks, todoList.Trash} {
+		for _, tk := range list {
+			ranks[tk.ID] = tk.Rank
+		}
+	}
+	if want := map[int]string{2: "00001i", 3: "00002i", 5: "00003i"}; !reflect.DeepEqual(ranks, want) {
+		t.Errorf("posições = %v, esperado %v", ranks, want)
+	}
+	if _, err := os.Stat(path + ".v6.bak"); err != nil {
+		t.Errorf("backup da versão 6: %v", err)
+	}
+}
+
+// Dois processos carregam o mesmo banco e salvam em sequência: o segundo
+// recebe um conflito com as duas versões e pode gravar a mesclagem
+func TestSQLiteSaveConflict(t *testing.T) {
+	path := tempDB(t)
+	tl := task.NewTodoList()
+	tl.AddTask("deploy", "")
+	if err := NewSQLiteStorage(path).Save(tl); err != nil {
+		t.Fatal(err)
+	}
+
+	first, second := NewSQLiteStorage(path), NewSQLiteStorage(path)
+	ours, err := first.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	theirs, err := second.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	theirs.AddTask("backup", "")
+	if err := second.Save(theirs); err != nil {
+		t.Fatal(err)
+	}
+
+	ours.AddTask("relatório", "")
+	err = first.Save(ours)
+	var conflict *ConflictError
+	if !errors.As(err, &conflict) {
+		t.Fatalf("Save concorrente: erro %v, esperado um ConflictError", err)
+	}
+	if len(conflict.Base.Tasks) != 1 || len(conflict.Theirs.Tasks) != 2 {
+		t.Errorf("conflito com %d tarefa(s) na base e %d na outra versão",
+			len(conflict.Base.Tasks), len(conflict.Theirs.Tasks))
+	}
+	if titles := titlesOf(reload(t, path)); len(titles) != 2 {
+		t.Errorf("o Save com conflito gravou: %v", titles)
+	}
+
+	merged, _ := task.Merge(conflict.Base, ours, conflict.Theirs)
+	if err := first.Save(merged); err != nil {
+		t.Fatalf("Save após o conflito: %v", err)
+	}
+	loaded := reload(t, path)
+	assertSameList(t, loaded, merged)
+	if loaded.NextID != 4 {
+		t.Errorf("NextID = %d, esperado 4", loaded.NextID)
+	}
+}
+
+// Projetos e visões são gravados por diferença: alterar, remover e recriar
+// com outra caixa chega ao banco como na lista
+func TestSQLiteSaveProjectsAndViews(t *testing.T) {
+	path := tempDB(t)
+	s := NewSQLiteStorage(path)
+
+	tl := task.NewTodoList()
+	for _, name := range []string{"casa", "trabalho", "estudos"} {
+		if _, err := tl.AddProject(name); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, name := range []string{"infra", "hoje"} {
+		if _, _, err := tl.SaveView(name, "tag:"+name, ""); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := s.Save(tl); err != nil {
+		t.Fatal(err)
+	}
+
+	tl.Projects[0].Archived = true
+	tl.Projects = tl.Projects[:2]
+	if err := tl.RemoveView("infra"); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := tl.SaveView("INFRA", "tag:infra status:pending", "priority:desc"); err != nil {
+		t.Fatal(err)
+	}
+	if err := tl.SetViewPinned("hoje", true); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Save(tl); err != nil {
+		t.Fatal(err)
+	}
+	assertSameList(t, reload(t, path), tl)
+}
+
+// As ordenações padrão das listagens são gravadas por diferença, e voltar
+// uma listagem à ordem padrão apaga a linha
+func TestSQLiteListingSorts(t *testing.T) {
+	path := tempDB(t)
+	s := NewSQLiteStorage(path)
+
+	tl := task.NewTodoList()
+	for listing, spec := range map[string]string{"list": "due", "search": "updated", "ready": "rank"} {
+		if err := tl.SetListingSort(listing, spec); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := s.Save(tl); err != nil {
+		t.Fatal(err)
+	}
+	assertSameList(t, reload(t, path), tl)
+
+	for listing, spec := range map[string]string{"list": "title", "search": "", "pending": "id"} {
+		if err := tl.SetListingSort(listing, spec); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := s.Save(tl); err != nil {
+		t.Fatal(err)
+	}
+	assertSameList(t, reload(t, path), tl)
+}
+
+// titlesOf lista os títulos das tarefas
+func titlesOf(todoList *task.TodoList) []string {
+	var titles []string
+	for _, t := range todoList.Tasks {
+		titles = append(titles, t.Title)
+	}
+	return titles
+}
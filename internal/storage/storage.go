@@ -1,6 +1,10 @@
 package storage
 
 import (
+	"errors"
+	"fmt"
+	"strings"
+
 	"github.com/lucianoZgabriel/go-cli-todo/internal/task"
 )
 
@@ -8,3 +12,59 @@ type Storage interface {
 	Save(todoList *task.TodoList) error
 	Load() (*task.TodoList, error)
 }
+
+// ErrConflict indica que os dados foram alterados por outro processo
+// desde o último Load
+var ErrConflict = errors.New("dados alterados por outro processo")
+
+// ConflictError é retornado por Save quando o arquivo mudou desde o último
+// Load. Base é a versão carregada e Theirs a versão gravada pelo outro
+// processo. Após o conflito o storage passa a considerar Theirs como base:
+// um novo Save sobrescreve, a menos que ocorra outra gravação concorrente.
+type ConflictError struct {
+	Base   *task.TodoList
+	Theirs *task.TodoList
+}
+
+func (e *ConflictError) Error() string {
+	return ErrConflict.Error()
+}
+
+func (e *ConflictError) Unwrap() error {
+	return ErrConflict
+}
+
+// HistoryStore é implementado por storages capazes de persistir o
+// histórico de desfazer/refazer, para que ele sobreviva entre execuções
+type HistoryStore interface {
+	SaveHistory(history *task.History) error
+	LoadHistory() (*task.History, error)
+}
+
+// Recoverer é implementado por storages capazes de se recuperar de um
+// arquivo corrompido usando uma cópia de segurança
+type Recoverer interface {
+	// Recovered retorna o motivo da recuperação no último Load, ou nil
+	Recovered() error
+}
+
+// New cria o storage descrito por spec, no formato "<tipo>:<caminho>".
+// Tipos suportados: json e sqlite. Um caminho sem tipo usa JSON.
+func New(spec string) (Storage, error) {
+	kind, path, found := strings.Cut(spec, ":")
+	if !found {
+		kind, path = "json", spec
+	}
+	if strings.TrimSpace(path) == "" {
+		return nil, fmt.Errorf("storage sem caminho: %s (ex.: json:tasks.json, sqlite:tasks.db)", spec)
+	}
+
+	switch strings.ToLower(kind) {
+	case "json":
+		return NewJSONStorage(path), nil
+	case "sqlite":
+		return NewSQLiteStorage(path), nil
+	default:
+		return nil, fmt.Errorf("tipo de storage desconhecido: %s (use json ou sqlite)", kind)
+	}
+}
@@ -0,0 +1,231 @@
+package task
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// EventKind identifica o tipo de um evento do histórico de uma tarefa
+type EventKind string
+
+// Tipos de evento registrados por Track
+const (
+	EventCreated   EventKind = "created"
+	EventEdited    EventKind = "edited"
+	EventCompleted EventKind = "completed"
+	EventReopened  EventKind = "reopened"
+	EventDeleted   EventKind = "deleted"
+	EventRestored  EventKind = "restored"
+)
+
+// Campos comparados por Track, usados em FieldChange.Field
+const (
+	FieldTitle       = "title"
+	FieldDescription = "description"
+	FieldPriority    = "priority"
+	FieldDue         = "due"
+	FieldTags        = "tags"
+	FieldProject     = "project"
+	FieldParent      = "parent"
+	FieldBlockedBy   = "blocked_by"
+	FieldRecurrence  = "recurrence"
+)
+
+// fieldLabels nomeia os campos para exibição
+var fieldLabels = map[string]string{
+	FieldTitle:       "Título",
+	FieldDescription: "Descrição",
+	FieldPriority:    "Prioridade",
+	FieldDue:         "Prazo",
+	FieldTags:        "Tags",
+	FieldProject:     "Projeto",
+	FieldParent:      "Tarefa principal",
+	FieldBlockedBy:   "Dependências",
+	FieldRecurrence:  "Repetição",
+}
+
+// FieldLabel retorna o nome de exibição de um campo
+func FieldLabel(field string) string {
+	if label, ok := fieldLabels[field]; ok {
+		return label
+	}
+	return field
+}
+
+// FieldChange guarda o valor de um campo antes e depois de uma edição,
+// já formatado para exibição. Um valor vazio indica campo sem valor.
+type FieldChange struct {
+	Field string `json:"field"`
+	From  string `json:"from,omitempty"`
+	To    string `json:"to,omitempty"`
+}
+
+// Event é uma entrada do histórico de auditoria de uma tarefa. Os eventos
+// só são acrescentados, nunca alterados ou removidos.
+type Event struct {
+	At      time.Time     `json:"at"`
+	Kind    EventKind     `json:"kind"`
+	Changes []FieldChange `json:"changes,omitempty"`
+}
+
+// Track executa fn, que altera a lista, e acrescenta ao histórico de cada
+// tarefa afetada os eventos correspondentes, atualizando UpdatedAt. Mesmo
+// que fn falhe, as alterações já feitas são registradas.
+//
+// Os eventos anteriores de cada tarefa são sempre preservados, inclusive
+// quando fn devolve a tarefa a um estado antigo (como ao desfazer): nesse
+// caso a volta também entra no histórico.
+func (tl *TodoList) Track(fn func() error) error {
+	before := tl.trackedTasks()
+	err := fn()
+
+	now := time.Now()
+	for _, list := range [][]Task{tl.Tasks, tl.Trash} {
+		for i := range list {
+			after := &list[i]
+			prev, existed := before[after.ID]
+			if !existed {
+				if len(after.Events) == 0 {
+					after.Events = []Event{{At: now, Kind: EventCreated}}
+				}
+				continue
+			}
+
+			events := tl.taskEvents(&prev, after, now)
+			after.Events = append(append([]Event(nil), prev.Events...), events...)
+			after.UpdatedAt = prev.UpdatedAt
+			if len(events) > 0 {
+				updatedAt := now
+				after.UpdatedAt = &updatedAt
+			}
+		}
+	}
+	return err
+}
+
+// trackedTasks copia as tarefas da lista e da lixeira, indexadas por ID
+func (tl *TodoList) trackedTasks() map[int]Task {
+	tasks := make(map[int]Task, len(tl.Tasks)+len(tl.Trash))
+	for _, list := range [][]Task{tl.Tasks, tl.Trash} {
+		for i := range list {
+			tasks[list[i].ID] = cloneTask(&list[i])
+		}
+	}
+	return tasks
+}
+
+// taskEvents compara duas versões de uma tarefa e monta os eventos que
+// levam de before a after
+func (tl *TodoList) taskEvents(before, after *Task, now time.Time) []Event {
+	var events []Event
+	switch {
+	case !before.IsDeleted() && after.IsDeleted():
+		events = append(events, Event{At: now, Kind: EventDeleted})
+	case before.IsDeleted() && !after.IsDeleted():
+		events = append(events, Event{At: now, Kind: EventRestored})
+	}
+
+	if changes := tl.fieldChanges(before, after); len(changes) > 0 {
+		events = append(events, Event{At: now, Kind: EventEdited, Changes: changes})
+	}
+
+	switch {
+	case !before.Completed && after.Completed:
+		events = append(events, Event{At: now, Kind: EventCompleted})
+	case before.Completed && !after.Completed:
+		events = append(events, Event{At: now, Kind: EventReopened})
+	}
+	return events
+}
+
+// fieldChanges lista os campos editáveis que diferem entre as versões
+func (tl *TodoList) fieldChanges(before, after *Task) []FieldChange {
+	var changes []FieldChange
+	add := func(field, from, to string) {
+		if from != to {
+			changes = append(changes, FieldChange{Field: field, From: from, To: to})
+		}
+	}
+
+	add(FieldTitle, before.Title, after.Title)
+	add(FieldDescription, before.Description, after.Description)
+	add(FieldPriority, priorityValue(before.Priority), priorityValue(after.Priority))
+	add(FieldDue, before.FormatDue(), after.FormatDue())
+	add(FieldTags, strings.Join(before.Tags, ", "), strings.Join(after.Tags, ", "))
+	add(FieldProject, tl.ProjectName(before.ProjectID), tl.ProjectName(after.ProjectID))
+	add(FieldParent, idValue(before.ParentID), idValue(after.ParentID))
+	if !reflect.DeepEqual(before.BlockedBy, after.BlockedBy) {
+		add(FieldBlockedBy, idsValue(before.BlockedBy), idsValue(after.BlockedBy))
+	}
+	add(FieldRecurrence, recurrenceValue(before.Recurrence), recurrenceValue(after.Recurrence))
+	return changes
+}
+
+// Timeline retorna o histórico da tarefa em ordem cronológica. Tarefas
+// criadas antes da auditoria ganham eventos deduzidos de CreatedAt e
+// CompletedAt.
+func (t *Task) Timeline() []Event {
+	var timeline []Event
+	if len(t.Events) == 0 || t.Events[0].Kind != EventCreated {
+		timeline = append(timeline, Event{At: t.CreatedAt, Kind: EventCreated})
+	}
+	timeline = append(timeline, t.Events...)
+
+	if t.Completed && t.CompletedAt != nil && !t.hasEvent(EventCompleted) {
+		timeline = append(timeline, Event{At: *t.CompletedAt, Kind: EventCompleted})
+	}
+	return timeline
+}
+
+// hasEvent informa se a tarefa tem algum evento do tipo informado
+func (t *Task) hasEvent(kind EventKind) bool {
+	for _, event := range t.Events {
+		if event.Kind == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// FindTask busca uma tarefa por ID na lista ou na lixeira
+func (tl *TodoList) FindTask(id int) (*Task, error) {
+	if i := tl.trashIndex(id); i >= 0 {
+		return &tl.Trash[i], nil
+	}
+	return tl.GetTask(id)
+}
+
+// priorityValue formata a prioridade, com "none" como valor vazio
+func priorityValue(p Priority) string {
+	if p == PriorityNone {
+		return ""
+	}
+	return p.String()
+}
+
+// recurrenceValue formata a regra de repetição, se houver
+func recurrenceValue(r *Recurrence) string {
+	if r == nil {
+		return ""
+	}
+	return r.String()
+}
+
+// idValue formata uma referência opcional a outra tarefa
+func idValue(id int) string {
+	if id == 0 {
+		return ""
+	}
+	return "[" + strconv.Itoa(id) + "]"
+}
+
+// idsValue formata uma lista de referências a tarefas
+func idsValue(ids []int) string {
+	values := make([]string, len(ids))
+	for i, id := range ids {
+		values[i] = idValue(id)
+	}
+	return strings.Join(values, ", ")
+}
@@ -0,0 +1,187 @@
+package task
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// kinds lista os tipos dos eventos da tarefa, em ordem
+func kinds(t *Task) []EventKind {
+	var kinds []EventKind
+	for _, event := range t.Events {
+		kinds = append(kinds, event.Kind)
+	}
+	return kinds
+}
+
+// track executa fn com Track, falhando o teste se ela falhar
+func track(t *testing.T, tl *TodoList, fn func() error) {
+	t.Helper()
+	if err := tl.Track(fn); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestTrackEvents(t *testing.T) {
+	tl := NewTodoList()
+	if _, err := tl.AddProject("trabalho"); err != nil {
+		t.Fatal(err)
+	}
+	track(t, tl, func() error { tl.AddTask("deploy", ""); tl.AddTask("backup", ""); return nil })
+	track(t, tl, func() error {
+		if err := tl.EditTask(1, "deploy da API", "janela das 22h"); err != nil {
+			return err
+		}
+		if err := tl.SetPriority(1, PriorityHigh); err != nil {
+			return err
+		}
+		if err := tl.AddTags(1, "infra"); err != nil {
+			return err
+		}
+		if err := tl.MoveTask(1, "trabalho"); err != nil {
+			return err
+		}
+		return tl.AddDependency(1, 2)
+	})
+
+	deploy, _ := tl.GetTask(1)
+	if want := []EventKind{EventCreated, EventEdited}; !reflect.DeepEqual(kinds(deploy), want) {
+		t.Fatalf("eventos %v, esperado %v", kinds(deploy), want)
+	}
+	want := []FieldChange{
+		{Field: FieldTitle, From: "deploy", To: "deploy da API"},
+		{Field: FieldDescription, To: "janela das 22h"},
+		{Field: FieldPriority, To: "high"},
+		{Field: FieldTags, To: "infra"},
+		{Field: FieldProject, From: DefaultProjectName, To: "trabalho"},
+		{Field: FieldBlockedBy, To: "[2]"},
+	}
+	if changes := deploy.Events[1].Changes; !reflect.DeepEqual(changes, want) {
+		t.Errorf("campos alterados:\n%+v\nesperado:\n%+v", changes, want)
+	}
+	if deploy.UpdatedAt == nil {
+		t.Error("UpdatedAt não atualizado pela edição")
+	}
+
+	// A tarefa não afetada não ganha eventos nem data de alteração
+	backup, _ := tl.GetTask(2)
+	if want := []EventKind{EventCreated}; !reflect.DeepEqual(kinds(backup), want) || backup.UpdatedAt != nil {
+		t.Errorf("tarefa intocada: eventos %v, alterada em %v", kinds(backup), backup.UpdatedAt)
+	}
+
+	track(t, tl, func() error { return tl.ToggleTask(2) })
+	track(t, tl, func() error { return tl.ToggleTask(2) })
+	track(t, tl, func() error { return tl.RemoveTask(2) })
+	track(t, tl, func() error { _, err := tl.RestoreTask(2); return err })
+
+	backup, _ = tl.GetTask(2)
+	want2 := []EventKind{EventCreated, EventCompleted, EventReopened, EventDeleted, EventRestored}
+	if !reflect.DeepEqual(kinds(backup), want2) {
+		t.Errorf("eventos %v, esperado %v", kinds(backup), want2)
+	}
+	// Remover a bloqueadora também editou as dependências da outra tarefa
+	deploy, _ = tl.GetTask(1)
+	if last := deploy.Events[len(deploy.Events)-1]; last.Kind != EventEdited || last.Changes[0].Field != FieldBlockedBy {
+		t.Errorf("último evento da dependente: %+v", last)
+	}
+}
+
+// Operações que falham no meio registram o que chegaram a alterar
+func TestTrackPartialFailure(t *testing.T) {
+	tl := NewTodoList()
+	track(t, tl, func() error { tl.AddTask("deploy", ""); return nil })
+	failure := errors.New("falhou")
+
+	err := tl.Track(func() error {
+		if err := tl.EditTask(1, "deploy da API", ""); err != nil {
+			return err
+		}
+		return failure
+	})
+	if !errors.Is(err, failure) {
+		t.Errorf("erro %v, esperado %v", err, failure)
+	}
+	if want := []EventKind{EventCreated, EventEdited}; !reflect.DeepEqual(kinds(&tl.Tasks[0]), want) {
+		t.Errorf("eventos %v, esperado %v", kinds(&tl.Tasks[0]), want)
+	}
+}
+
+// Desfazer e refazer, feitos dentro de Track como na CLI, não apagam
+// eventos: a volta ao estado anterior também entra no histórico da tarefa
+func TestTrackUndo(t *testing.T) {
+	tl := NewTodoList()
+	h := NewHistory(DefaultHistoryDepth)
+	tracked := func(fn func() error) {
+		record(t, h, tl, func() error { return tl.Track(fn) })
+	}
+	undo := func() {
+		t.Helper()
+		track(t, tl, func() error { _, err := h.UndoLast(tl); return err })
+	}
+	redo := func() {
+		t.Helper()
+		track(t, tl, func() error { _, err := h.RedoLast(tl); return err })
+	}
+
+	tracked(func() error { tl.AddTask("deploy", ""); return nil })
+	tracked(func() error { return tl.EditTask(1, "deploy da API", "") })
+	undo()
+
+	deploy := &tl.Tasks[0]
+	if deploy.Title != "deploy" {
+		t.Fatalf("título após desfazer: %q", deploy.Title)
+	}
+	if want := []EventKind{EventCreated, EventEdited, EventEdited}; !reflect.DeepEqual(kinds(deploy), want) {
+		t.Fatalf("eventos após desfazer %v, esperado %v", kinds(deploy), want)
+	}
+	if change := deploy.Events[2].Changes[0]; change.From != "deploy da API" || change.To != "deploy" {
+		t.Errorf("evento da volta: %+v", change)
+	}
+
+	redo()
+	if n := len(tl.Tasks[0].Events); n != 4 {
+		t.Errorf("refazer: %d eventos, esperado 4", n)
+	}
+
+	// Desfazer uma remoção restaura a tarefa com o histórico da lixeira
+	tracked(func() error { return tl.RemoveTask(1) })
+	undo()
+	want := []EventKind{EventCreated, EventEdited, EventEdited, EventEdited, EventDeleted, EventRestored}
+	if !reflect.DeepEqual(kinds(&tl.Tasks[0]), want) {
+		t.Errorf("eventos após desfazer a remoção %v, esperado %v", kinds(&tl.Tasks[0]), want)
+	}
+
+	// Desfazer a criação apaga a tarefa; refazer a traz com os eventos que
+	// ela tinha quando foi criada
+	h = NewHistory(DefaultHistoryDepth)
+	tracked(func() error { tl.AddTask("backup", ""); return nil })
+	undo()
+	if len(tl.Tasks) != 1 {
+		t.Fatalf("tarefas após desfazer a criação: %v", ids(tl.Tasks))
+	}
+	redo()
+	if backup, err := tl.GetTask(2); err != nil || !reflect.DeepEqual(kinds(backup), []EventKind{EventCreated}) {
+		t.Errorf("tarefa recriada: %v, erro %v", backup, err)
+	}
+}
+
+// Tarefas gravadas antes da auditoria ganham eventos deduzidos das datas
+func TestTimelineWithoutEvents(t *testing.T) {
+	created := time.Date(2026, time.October, 1, 9, 0, 0, 0, time.UTC)
+	completed := created.Add(48 * time.Hour)
+	legacy := Task{ID: 1, Title: "antiga", Completed: true, CreatedAt: created, CompletedAt: &completed}
+
+	timeline := legacy.Timeline()
+	if len(timeline) != 2 || !timeline[0].At.Equal(created) || timeline[1].Kind != EventCompleted ||
+		!timeline[1].At.Equal(completed) {
+		t.Errorf("linha do tempo deduzida: %+v", timeline)
+	}
+
+	// Com eventos registrados, nada é deduzido em duplicidade
+	legacy.Events = []Event{{At: created, Kind: EventCreated}, {At: completed, Kind: EventCompleted}}
+	if timeline := legacy.Timeline(); len(timeline) != 2 {
+		t.Errorf("linha do tempo com eventos: %+v", timeline)
+	}
+}
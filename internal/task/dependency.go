@@ -0,0 +1,145 @@
+package task
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// ErrDependencyCycle indica que a dependência criaria um ciclo de bloqueios
+var ErrDependencyCycle = errors.New("dependência criaria um ciclo")
+
+// AddDependency registra que a tarefa id só pode começar depois que
+// blockerID for concluída. Dependências que formariam ciclo são rejeitadas.
+func (tl *TodoList) AddDependency(id, blockerID int) error {
+	task, err := tl.GetTask(id)
+	if err != nil {
+		return err
+	}
+	if _, err := tl.GetTask(blockerID); err != nil {
+		return err
+	}
+
+	if id == blockerID || tl.dependsOn(blockerID, id) {
+		return fmt.Errorf("%w: [%d] já depende de [%d]", ErrDependencyCycle, blockerID, id)
+	}
+
+	if !containsInt(task.BlockedBy, blockerID) {
+		task.BlockedBy = append(task.BlockedBy, blockerID)
+		sort.Ints(task.BlockedBy)
+	}
+	return nil
+}
+
+// RemoveDependency remove a dependência entre id e blockerID, se existir
+func (tl *TodoList) RemoveDependency(id, blockerID int) error {
+	task, err := tl.GetTask(id)
+	if err != nil {
+		return err
+	}
+
+	task.BlockedBy = removeInt(task.BlockedBy, blockerID)
+	return nil
+}
+
+// Blockers retorna as tarefas das quais a tarefa depende
+func (tl *TodoList) Blockers(id int) []Task {
+	task, err := tl.GetTask(id)
+	if err != nil {
+		return nil
+	}
+
+	var blockers []Task
+	for _, blockerID := range task.BlockedBy {
+		if blocker, err := tl.GetTask(blockerID); err == nil {
+			blockers = append(blockers, *blocker)
+		}
+	}
+	return blockers
+}
+
+// OpenBlockers retorna as tarefas pendentes que ainda bloqueiam a tarefa
+func (tl *TodoList) OpenBlockers(id int) []Task {
+	var open []Task
+	for _, blocker := range tl.Blockers(id) {
+		if !blocker.Completed {
+			open = append(open, blocker)
+		}
+	}
+	return open
+}
+
+// IsBlocked informa se a tarefa tem alguma dependência pendente
+func (tl *TodoList) IsBlocked(id int) bool {
+	return len(tl.OpenBlockers(id)) > 0
+}
+
+// Dependents retorna as tarefas que dependem da tarefa informada
+func (tl *TodoList) Dependents(id int) []Task {
+	var dependents []Task
+	for _, task := range tl.Tasks {
+		if containsInt(task.BlockedBy, id) {
+			dependents = append(dependents, task)
+		}
+	}
+	return dependents
+}
+
+// ReadyTasks retorna as tarefas pendentes sem dependências em aberto,
+// ordenadas por prioridade e posição
+func (tl *TodoList) ReadyTasks() []Task {
+	var ready []Task
+	for _, task := range tl.Tasks {
+		if !task.Completed && !tl.IsBlocked(task.ID) {
+			ready = append(ready, task)
+		}
+	}
+	SortByPriority(ready)
+	return ready
+}
+
+// dependsOn informa se a tarefa id depende, direta ou indiretamente, de target
+func (tl *TodoList) dependsOn(id, target int) bool {
+	visited := make(map[int]bool)
+	stack := []int{id}
+	for len(stack) > 0 {
+		current := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if visited[current] {
+			continue
+		}
+		visited[current] = true
+
+		task, err := tl.GetTask(current)
+		if err != nil {
+			continue
+		}
+		for _, blockerID := range task.BlockedBy {
+			if blockerID == target {
+				return true
+			}
+			stack = append(stack, blockerID)
+		}
+	}
+	return false
+}
+
+// dropDependencies remove das demais tarefas as referências aos IDs removidos
+func (tl *TodoList) dropDependencies(ids ...int) {
+	for i := range tl.Tasks {
+		for _, id := range ids {
+			tl.Tasks[i].BlockedBy = removeInt(tl.Tasks[i].BlockedBy, id)
+		}
+	}
+}
+
+// removeInt retorna list sem as ocorrências de n; lista vazia vira nil
+func removeInt(list []int, n int) []int {
+	var kept []int
+	for _, item := range list {
+		if item != n {
+			kept = append(kept, item)
+		}
+	}
+	return kept
+}
@@ -0,0 +1,199 @@
+package task
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Formatos aceitos para datas de vencimento
+var (
+	dueDateLayouts     = []string{"2006-01-02", "02/01/2006"}
+	dueDateTimeLayouts = []string{"2006-01-02 15:04", "02/01/2006 15:04"}
+)
+
+// ParseDue interpreta um prazo informado pelo usuário. Aceita datas nos
+// formatos AAAA-MM-DD ou DD/MM/AAAA, opcionalmente seguidas de HH:MM, além de
+// "hoje" e "amanhã" (ou "today" e "tomorrow"). Retorna se o prazo tem horário.
+func ParseDue(s string, now time.Time) (due time.Time, hasTime bool, err error) {
+	s = strings.TrimSpace(s)
+
+	switch strings.ToLower(s) {
+	case "hoje", "today":
+		return startOfDay(now), false, nil
+	case "amanhã", "amanha", "tomorrow":
+		return startOfDay(now).AddDate(0, 0, 1), false, nil
+	}
+
+	for _, layout := range dueDateTimeLayouts {
+		if due, err := time.ParseInLocation(layout, s, now.Location()); err == nil {
+			return due, true, nil
+		}
+	}
+	for _, layout := range dueDateLayouts {
+		if due, err := time.ParseInLocation(layout, s, now.Location()); err == nil {
+			return due, false, nil
+		}
+	}
+
+	return time.Time{}, false, fmt.Errorf("prazo inválido: %s (use AAAA-MM-DD [HH:MM], DD/MM/AAAA [HH:MM], hoje ou amanhã)", s)
+}
+
+// startOfDay retorna a meia-noite do dia de t
+func startOfDay(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+}
+
+// endOfWeek retorna o início da segunda-feira seguinte à semana de t
+func endOfWeek(t time.Time) time.Time {
+	daysUntilSunday := (7 - int(t.Weekday())) % 7
+	return startOfDay(t).AddDate(0, 0, daysUntilSunday+1)
+}
+
+// HasDue informa se a tarefa tem prazo definido
+func (t *Task) HasDue() bool {
+	return t.DueDate != nil
+}
+
+// IsOverdue informa se a tarefa está pendente e com o prazo vencido.
+// Prazos sem horário só vencem quando o dia termina.
+func (t *Task) IsOverdue(now time.Time) bool {
+	if t.Completed || t.DueDate == nil {
+		return false
+	}
+	if t.DueHasTime {
+		return t.DueDate.Before(now)
+	}
+	return t.DueDate.Before(startOfDay(now))
+}
+
+// IsDueOn informa se o prazo da tarefa cai no mesmo dia de day
+func (t *Task) IsDueOn(day time.Time) bool {
+	if t.DueDate == nil {
+		return false
+	}
+	return startOfDay(t.DueDate.In(day.Location())).Equal(startOfDay(day))
+}
+
+// FormatDue formata o prazo para exibição, com ou sem horário
+func (t *Task) FormatDue() string {
+	if t.DueDate == nil {
+		return ""
+	}
+	if t.DueHasTime {
+		return t.DueDate.Format("02/01/2006 15:04")
+	}
+	return t.DueDate.Format("02/01/2006")
+}
+
+// SetDueDate define o prazo de uma tarefa. Um prazo nil remove o prazo atual.
+func (tl *TodoList) SetDueDate(id int, due *time.Time, hasTime bool) error {
+	task, err := tl.GetTask(id)
+	if err != nil {
+		return err
+	}
+
+	if due == nil {
+		task.DueDate = nil
+		task.DueHasTime = false
+		return nil
+	}
+
+	value := *due
+	if !hasTime {
+		value = startOfDay(value)
+	}
+	task.DueDate = &value
+	task.DueHasTime = hasTime
+	return nil
+}
+
+// OverdueTasks retorna as tarefas pendentes com prazo vencido
+func (tl *TodoList) OverdueTasks(now time.Time) []Task {
+	return tl.filterDue(func(t *Task) bool {
+		return t.IsOverdue(now)
+	})
+}
+
+// DueTodayTasks retorna as tarefas pendentes que vencem hoje e ainda não venceram
+func (tl *TodoList) DueTodayTasks(now time.Time) []Task {
+	return tl.filterDue(func(t *Task) bool {
+		return t.IsDueOn(now) && !t.IsOverdue(now)
+	})
+}
+
+// DueThisWeekTasks retorna as tarefas pendentes que vencem entre agora e o
+// fim da semana corrente (domingo), sem incluir as atrasadas
+func (tl *TodoList) DueThisWeekTasks(now time.Time) []Task {
+	limit := endOfWeek(now)
+	return tl.filterDue(func(t *Task) bool {
+		return !t.IsOverdue(now) && t.DueDate.Before(limit)
+	})
+}
+
+// CountOverdue retorna quantas tarefas estão atrasadas
+func (tl *TodoList) CountOverdue(now time.Time) int {
+	count := 0
+	for i := range tl.Tasks {
+		if tl.Tasks[i].IsOverdue(now) {
+			count++
+		}
+	}
+	return count
+}
+
+// filterDue retorna as tarefas pendentes com prazo que satisfazem match,
+// ordenadas pelo prazo
+func (tl *TodoList) filterDue(match func(t *Task) bool) []Task {
+	var result []Task
+	for i := range tl.Tasks {
+		t := &tl.Tasks[i]
+		if t.Completed || t.DueDate == nil {
+			continue
+		}
+		if match(t) {
+			result = append(result, *t)
+		}
+	}
+	sortByDue(result)
+	return result
+}
+
+// sortByDue ordena tarefas pelo prazo e, em seguida, por prioridade
+func sortByDue(tasks []Task) {
+	sort.SliceStable(tasks, func(i, j int) bool {
+		if !tasks[i].DueDate.Equal(*tasks[j].DueDate) {
+			return tasks[i].DueDate.Before(*tasks[j].DueDate)
+		}
+		return tasks[i].Priority > tasks[j].Priority
+	})
+}
+
+// AgendaDay agrupa as tarefas que vencem em um mesmo dia
+type AgendaDay struct {
+	Date  time.Time
+	Tasks []Task
+}
+
+// Agenda retorna as tarefas pendentes atrasadas e as que vencem nos próximos
+// days dias (incluindo hoje), agrupadas por dia de vencimento
+func (tl *TodoList) Agenda(now time.Time, days int) (overdue []Task, agenda []AgendaDay) {
+	overdue = tl.OverdueTasks(now)
+
+	limit := startOfDay(now).AddDate(0, 0, days)
+	upcoming := tl.filterDue(func(t *Task) bool {
+		return !t.IsOverdue(now) && t.DueDate.Before(limit)
+	})
+
+	for _, t := range upcoming {
+		day := startOfDay(t.DueDate.In(now.Location()))
+		if len(agenda) == 0 || !agenda[len(agenda)-1].Date.Equal(day) {
+			agenda = append(agenda, AgendaDay{Date: day})
+		}
+		agenda[len(agenda)-1].Tasks = append(agenda[len(agenda)-1].Tasks, t)
+	}
+
+	return overdue, agenda
+}
@@ -0,0 +1,331 @@
+package task
+
+import (
+	"strings"
+	"unicode"
+)
+
+// MatchKind classifica como um termo casou com um texto. Quanto maior,
+// mais relevante.
+type MatchKind int
+
+const (
+	MatchNone         MatchKind = iota
+	MatchTypo                   // palavra com até um ou dois erros de digitação
+	MatchSubsequence            // letras na ordem, não necessariamente juntas
+	MatchSubstring              // trecho no meio de uma palavra
+	MatchWordBoundary           // trecho no início de uma palavra
+	MatchPrefix                 // início do texto
+	MatchExact                  // texto inteiro
+)
+
+// kindScore é a pontuação base de cada tipo de correspondência. Os bônus
+// somados dentro de um tipo ficam abaixo de matchBonusLimit, para que um
+// tipo nunca ultrapasse o seguinte.
+var kindScore = map[MatchKind]int{
+	MatchTypo:         100,
+	MatchSubsequence:  200,
+	MatchSubstring:    300,
+	MatchWordBoundary: 400,
+	MatchPrefix:       500,
+	MatchExact:        600,
+}
+
+// matchBonusLimit é o maior bônus somado à pontuação base
+const matchBonusLimit = 99
+
+// minTypoLength é o tamanho mínimo de um termo para tolerar erros de
+// digitação: termos curtos casariam com palavras demais
+const minTypoLength = 4
+
+// Match é o resultado de FuzzyMatch: o tipo, a pontuação e as posições (em
+// runas) do texto que casaram com o termo
+type Match struct {
+	Kind      MatchKind
+	Score     int
+	Positions []int
+}
+
+// FuzzyMatch procura o termo no texto sem diferenciar maiúsculas nem
+// acentos ("acao" casa com "Ação"). Na ordem de relevância: texto
+// idêntico, início do texto, início de uma palavra, trecho qualquer, letras
+// em sequência numa palavra ("dply" em "deploy") e, por fim, uma palavra a
+// um ou dois erros de digitação ("deplyo"). O segundo retorno é false se
+// nada casar.
+func FuzzyMatch(term, text string) (Match, bool) {
+	pattern := foldRunes(term)
+	runes := foldRunes(text)
+	if len(pattern) == 0 || len(runes) == 0 {
+		return Match{}, false
+	}
+
+	if start := bestSubstring(pattern, runes); start >= 0 {
+		kind := MatchSubstring
+		switch {
+		case len(pattern) == len(runes):
+			kind = MatchExact
+		case start == 0:
+			kind = MatchPrefix
+		case isWordStart(runes, start):
+			kind = MatchWordBoundary
+		}
+		// Textos mais curtos são correspondências mais precisas
+		bonus := matchBonusLimit - min(len(runes)-len(pattern), matchBonusLimit)
+		return newMatch(kind, bonus, span(start, len(pattern))), true
+	}
+
+	if positions, bonus, ok := subsequence(pattern, runes); ok {
+		return newMatch(MatchSubsequence, bonus, positions), true
+	}
+
+	if start, length, distance, ok := typoMatch(pattern, runes); ok {
+		return newMatch(MatchTypo, matchBonusLimit-distance*10, span(start, length)), true
+	}
+
+	return Match{}, false
+}
+
+// newMatch monta um Match limitando o bônus
+func newMatch(kind MatchKind, bonus int, positions []int) Match {
+	bonus = max(0, min(bonus, matchBonusLimit))
+	return Match{Kind: kind, Score: kindScore[kind] + bonus, Positions: positions}
+}
+
+// foldRunes converte o texto em runas minúsculas e sem acento, uma por
+// runa original, para que as posições valham também para o texto original
+func foldRunes(s string) []rune {
+	runes := []rune(s)
+	for i, r := range runes {
+		runes[i] = foldRune(r)
+	}
+	return runes
+}
+
+// foldRune converte uma letra para minúscula e sem acento ("Ç" → "c")
+func foldRune(r rune) rune {
+	r = unicode.ToLower(r)
+	if folded, ok := accentFolds[r]; ok {
+		return folded
+	}
+	return r
+}
+
+// accentFolds mapeia cada letra acentuada para a letra sem acento
+var accentFolds = buildAccentFolds("aàáâãäå", "cç", "eèéêë", "iìíîï", "nñ", "oòóôõö", "uùúûü", "yýÿ")
+
+// buildAccentFolds monta accentFolds: em cada grupo, a primeira letra é a
+// forma sem acento das demais
+func buildAccentFolds(groups ...string) map[rune]rune {
+	folds := make(map[rune]rune)
+	for _, group := range groups {
+		runes := []rune(group)
+		for _, r := range runes[1:] {
+			folds[r] = runes[0]
+		}
+	}
+	return folds
+}
+
+// containsFolded informa se value aparece em text, sem diferenciar
+// maiúsculas nem acentos
+func containsFolded(text, value string) bool {
+	return strings.Contains(string(foldRunes(text)), string(foldRunes(value)))
+}
+
+// isWordStart informa se a posição i começa uma palavra
+func isWordStart(runes []rune, i int) bool {
+	return i == 0 || !isWordRune(runes[i-1])
+}
+
+// isWord informa se todas as runas formam uma única palavra
+func isWord(runes []rune) bool {
+	for _, r := range runes {
+		if !isWordRune(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// isWordRune informa se r faz parte de uma palavra
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// span retorna as posições de start até start+length-1
+func span(start, length int) []int {
+	positions := make([]int, length)
+	for i := range positions {
+		positions[i] = start + i
+	}
+	return positions
+}
+
+// bestSubstring retorna onde o termo aparece no texto, preferindo o
+// início de uma palavra à primeira ocorrência, ou -1
+func bestSubstring(pattern, runes []rune) int {
+	first := -1
+	for start := 0; start+len(pattern) <= len(runes); start++ {
+		if !hasRunesAt(runes, pattern, start) {
+			continue
+		}
+		if isWordStart(runes, start) {
+			return start
+		}
+		if first < 0 {
+			first = start
+		}
+	}
+	return first
+}
+
+// hasRunesAt informa se pattern aparece em runes a partir de start
+func hasRunesAt(runes, pattern []rune, start int) bool {
+	for i, r := range pattern {
+		if runes[start+i] != r {
+			return false
+		}
+	}
+	return true
+}
+
+// subsequence procura as letras do termo em ordem dentro de uma mesma
+// palavra, a partir do seu início ("dpl" em "deploy", mas "pao" não casa
+// com "rollback tool"). O bônus premia letras consecutivas e desconta as
+// letras puladas; vale a melhor das palavras.
+func subsequence(pattern, runes []rune) ([]int, int, bool) {
+	if len(pattern) < 2 || !isWord(pattern) {
+		return nil, 0, false
+	}
+
+	var best []int
+	bestBonus := 0
+	for start := range runes {
+		if runes[start] != pattern[0] || !isWordStart(runes, start) {
+			continue
+		}
+		positions, ok := wordSubsequence(pattern, runes, start)
+		if !ok {
+			continue
+		}
+		if bonus := subsequenceBonus(positions); best == nil || bonus > bestBonus {
+			best, bestBonus = positions, bonus
+		}
+	}
+	return best, bestBonus, best != nil
+}
+
+// wordSubsequence casa as letras em ordem sem sair da palavra que começa
+// na posição start
+func wordSubsequence(pattern, runes []rune, start int) ([]int, bool) {
+	positions := []int{start}
+	next := start + 1
+	for _, r := range pattern[1:] {
+		for next < len(runes) && isWordRune(runes[next]) && runes[next] != r {
+			next++
+		}
+		if next == len(runes) || !isWordRune(runes[next]) {
+			return nil, false
+		}
+		positions = append(positions, next)
+		next++
+	}
+	return positions, true
+}
+
+// subsequenceBonus pontua uma subsequência encontrada
+func subsequenceBonus(positions []int) int {
+	bonus := 0
+	for i := 1; i < len(positions); i++ {
+		if positions[i-1] == positions[i]-1 {
+			bonus += 5
+		}
+	}
+	gaps := positions[len(positions)-1] - positions[0] + 1 - len(positions)
+	return bonus - gaps
+}
+
+// typoAllowance é quantos erros de digitação o termo tolera: nenhum em
+// termos curtos ou com mais de uma palavra, um, ou dois em termos longos
+func typoAllowance(pattern []rune) int {
+	switch {
+	case len(pattern) < minTypoLength || !isWord(pattern):
+		return 0
+	case len(pattern) >= 8:
+		return 2
+	default:
+		return 1
+	}
+}
+
+// typoDistance compara o termo com a palavra, ou só com o seu início se
+// ela for mais longa que o termo mais os erros tolerados, e retorna o
+// tamanho do trecho comparado e a distância
+func typoDistance(pattern, word []rune, allowed int) (length, distance int) {
+	switch {
+	case len(word) < len(pattern)-allowed:
+		// Faltam mais letras do que os erros tolerados
+		return len(word), allowed + 1
+	case len(word) > len(pattern)+allowed:
+		return len(pattern), editDistance(pattern, word[:len(pattern)])
+	default:
+		return len(word), editDistance(pattern, word)
+	}
+}
+
+// typoMatch compara o termo com cada palavra do texto (veja typoDistance)
+// e retorna a mais próxima que está dentro da tolerância do termo
+func typoMatch(pattern, runes []rune) (start, length, distance int, ok bool) {
+	allowed := typoAllowance(pattern)
+	if allowed == 0 {
+		return 0, 0, 0, false
+	}
+
+	best := allowed + 1
+	for i := 0; i < len(runes); {
+		if !isWordRune(runes[i]) {
+			i++
+			continue
+		}
+		end := i
+		for end < len(runes) && isWordRune(runes[end]) {
+			end++
+		}
+		if n, d := typoDistance(pattern, runes[i:end], allowed); d < best {
+			best, start, length = d, i, n
+		}
+		i = end
+	}
+	if best > allowed {
+		return 0, 0, 0, false
+	}
+	return start, length, best, true
+}
+
+// editDistance é a distância de Damerau-Levenshtein restrita: inserções,
+// remoções, trocas e transposições de letras vizinhas ("deplyo" → "deploy")
+// contam um erro cada. Só as três últimas linhas da tabela são mantidas.
+func editDistance(a, b []rune) int {
+	prev2 := make([]int, len(b)+1)
+	prev := make([]int, len(b)+1)
+	row := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		row[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			row[j] = min(prev[j]+1, row[j-1]+1, prev[j-1]+cost)
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				row[j] = min(row[j], prev2[j-2]+1)
+			}
+		}
+		prev2, prev, row = prev, row, prev2
+	}
+	return prev[len(b)]
+}
@@ -0,0 +1,105 @@
+package task
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFuzzyMatch(t *testing.T) {
+	tests := []struct {
+		name      string
+		term      string
+		text      string
+		kind      MatchKind
+		positions []int
+	}{
+		{"texto idêntico", "deploy", "Deploy", MatchExact, []int{0, 1, 2, 3, 4, 5}},
+		{"início do texto", "dep", "Deploy API", MatchPrefix, []int{0, 1, 2}},
+		{"início de palavra", "api", "Deploy API", MatchWordBoundary, []int{7, 8, 9}},
+		{"trecho", "ploy", "Deploy API", MatchSubstring, []int{2, 3, 4, 5}},
+		{"acentos no texto", "acao", "Revisar ação", MatchWordBoundary, []int{8, 9, 10, 11}},
+		{"acentos no termo", "AÇÃO", "revisar acao", MatchWordBoundary, []int{8, 9, 10, 11}},
+		{"subsequência", "dpl", "Deploy API", MatchSubsequence, []int{0, 2, 3}},
+		{"subsequência na segunda palavra", "rlbk", "Dpl rollback tool", MatchSubsequence, []int{4, 6, 8, 11}},
+		{"letra faltando em subsequência", "kubernets", "kubernetes", MatchSubsequence, []int{0, 1, 2, 3, 4, 5, 6, 7, 9}},
+		{"transposição", "deplyo", "Deploy API", MatchTypo, []int{0, 1, 2, 3, 4, 5}},
+		{"letra trocada", "kubernetis", "kubernetes", MatchTypo, []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}},
+		{"dois erros em termo longo", "kuberentis", "kubernetes", MatchTypo, []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			match, ok := FuzzyMatch(tt.term, tt.text)
+			if !ok {
+				t.Fatalf("FuzzyMatch(%q, %q) não casou", tt.term, tt.text)
+			}
+			if match.Kind != tt.kind {
+				t.Errorf("tipo = %v, esperado %v", match.Kind, tt.kind)
+			}
+			if !reflect.DeepEqual(match.Positions, tt.positions) {
+				t.Errorf("posições = %v, esperado %v", match.Positions, tt.positions)
+			}
+		})
+	}
+}
+
+func TestFuzzyMatchRejects(t *testing.T) {
+	tests := []struct {
+		name string
+		term string
+		text string
+	}{
+		// A subsequência não atravessa palavras, para que o índice de busca
+		// possa responder por palavra: "rt" não casa com "rollback tool"
+		{"subsequência entre palavras", "rt", "rollback tool"},
+		{"iniciais de palavras diferentes", "dt", "deploy tool"},
+		{"subsequência fora do início da palavra", "ply", "deploy"},
+		{"termo curto não tolera erro", "dxp", "deploy"},
+		{"erros demais", "dxplxy", "deploy"},
+		{"texto vazio", "deploy", ""},
+		{"termo vazio", "", "deploy"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if match, ok := FuzzyMatch(tt.term, tt.text); ok {
+				t.Errorf("FuzzyMatch(%q, %q) casou como %v, esperado nenhuma correspondência", tt.term, tt.text, match.Kind)
+			}
+		})
+	}
+}
+
+func TestFuzzyMatchScoreOrder(t *testing.T) {
+	// Cada tipo de correspondência pontua acima de todos os menos relevantes
+	texts := []string{"deploy", "deploy da API", "o deploy", "redeploy", "dexploy", "deplyo"}
+	previous := 0
+	for i := len(texts) - 1; i >= 0; i-- {
+		match, ok := FuzzyMatch("deploy", texts[i])
+		if !ok {
+			t.Fatalf("FuzzyMatch(deploy, %q) não casou", texts[i])
+		}
+		if match.Score <= previous {
+			t.Errorf("%q pontuou %d, não mais que o tipo anterior (%d)", texts[i], match.Score, previous)
+		}
+		previous = match.Score
+	}
+}
+
+func TestEditDistance(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"deploy", "deploy", 0},
+		{"deplyo", "deploy", 1},
+		{"deploi", "deploy", 1},
+		{"depoy", "deploy", 1},
+		{"", "abc", 3},
+		{"kitten", "sitting", 3},
+	}
+	for _, tt := range tests {
+		if got := editDistance([]rune(tt.a), []rune(tt.b)); got != tt.want {
+			t.Errorf("editDistance(%q, %q) = %d, esperado %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
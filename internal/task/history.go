@@ -0,0 +1,349 @@
+package task
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"sort"
+	"time"
+)
+
+// DefaultHistoryDepth é a quantidade padrão de operações que podem ser desfeitas
+const DefaultHistoryDepth = 50
+
+var (
+	// ErrNothingToUndo indica que não há operação para desfazer
+	ErrNothingToUndo = errors.New("nada para desfazer")
+
+	// ErrNothingToRedo indica que não há operação para refazer
+	ErrNothingToRedo = errors.New("nada para refazer")
+)
+
+// TaskChange guarda uma tarefa antes e depois de uma operação. Before nil
+// indica uma tarefa criada; After nil, uma tarefa removida.
+type TaskChange struct {
+	ID     int   `json:"id"`
+	Before *Task `json:"before,omitempty"`
+	After  *Task `json:"after,omitempty"`
+}
+
+// Operation é uma alteração da lista que pode ser desfeita e refeita.
+// Guarda apenas as tarefas afetadas e, se mudaram, os projetos, a lixeira,
+// as visões salvas e as ordenações padrão das listagens.
+type Operation struct {
+	Label   string       `json:"label"`
+	At      time.Time    `json:"at"`
+	Changes []TaskChange `json:"changes,omitempty"`
+
+	ProjectsChanged bool      `json:"projects_changed,omitempty"`
+	ProjectsBefore  []Project `json:"projects_before,omitempty"`
+	ProjectsAfter   []Project `json:"projects_after,omitempty"`
+
+	TrashChanged bool   `json:"trash_changed,omitempty"`
+	TrashBefore  []Task `json:"trash_before,omitempty"`
+	TrashAfter   []Task `json:"trash_after,omitempty"`
+
+	ViewsChanged bool   `json:"views_changed,omitempty"`
+	ViewsBefore  []View `json:"views_before,omitempty"`
+	ViewsAfter   []View `json:"views_after,omitempty"`
+
+	SortsChanged bool              `json:"sorts_changed,omitempty"`
+	SortsBefore  map[string]string `json:"sorts_before,omitempty"`
+	SortsAfter   map[string]string `json:"sorts_after,omitempty"`
+}
+
+// History mantém as pilhas de desfazer e refazer, limitadas a Depth
+// operações. Depth zero desativa o histórico.
+type History struct {
+	Depth int         `json:"-"`
+	Undo  []Operation `json:"undo"`
+	Redo  []Operation `json:"redo"`
+}
+
+// NewHistory cria um histórico vazio com a profundidade informada
+func NewHistory(depth int) *History {
+	return &History{Depth: depth}
+}
+
+// Record executa fn, que altera tl, e registra a operação resultante para
+// poder desfazê-la. Se fn não alterar nada, nada é registrado; se falhar
+// depois de alterar a lista, a alteração parcial é registrada e o erro
+// retornado. Um label vazio é substituído por uma descrição gerada a
+// partir das alterações. Registrar uma operação descarta as que poderiam
+// ser refeitas.
+func (h *History) Record(tl *TodoList, label string, fn func() error) error {
+	if h.Depth <= 0 {
+		return fn()
+	}
+
+	before := captureState(tl)
+	err := fn()
+
+	op, changed := diffState(before, captureState(tl))
+	if !changed {
+		return err
+	}
+	op.Label = label
+	if op.Label == "" {
+		op.Label = op.describe()
+	}
+	op.At = time.Now()
+
+	h.Undo = append(h.Undo, op)
+	h.Redo = nil
+	h.trim()
+	return err
+}
+
+// UndoLast desfaz a última operação registrada e a retorna
+func (h *History) UndoLast(tl *TodoList) (*Operation, error) {
+	if len(h.Undo) == 0 {
+		return nil, ErrNothingToUndo
+	}
+
+	op := h.Undo[len(h.Undo)-1]
+	h.Undo = h.Undo[:len(h.Undo)-1]
+	op.apply(tl, false)
+	h.Redo = append(h.Redo, op)
+	return &op, nil
+}
+
+// RedoLast refaz a última operação desfeita e a retorna
+func (h *History) RedoLast(tl *TodoList) (*Operation, error) {
+	if len(h.Redo) == 0 {
+		return nil, ErrNothingToRedo
+	}
+
+	op := h.Redo[len(h.Redo)-1]
+	h.Redo = h.Redo[:len(h.Redo)-1]
+	op.apply(tl, true)
+	h.Undo = append(h.Undo, op)
+	return &op, nil
+}
+
+// trim descarta as operações mais antigas além da profundidade
+func (h *History) trim() {
+	if h.Depth <= 0 {
+		h.Undo, h.Redo = nil, nil
+		return
+	}
+	if extra := len(h.Undo) - h.Depth; extra > 0 {
+		h.Undo = append([]Operation(nil), h.Undo[extra:]...)
+	}
+	if extra := len(h.Redo) - h.Depth; extra > 0 {
+		h.Redo = append([]Operation(nil), h.Redo[extra:]...)
+	}
+}
+
+// SetDepth altera a profundidade, descartando operações excedentes
+func (h *History) SetDepth(depth int) {
+	h.Depth = depth
+	h.trim()
+}
+
+// apply leva a lista ao estado posterior (forward) ou anterior à operação.
+// NextID nunca diminui, para que IDs não sejam reaproveitados.
+func (op *Operation) apply(tl *TodoList, forward bool) {
+	for _, change := range op.Changes {
+		state := change.Before
+		if forward {
+			state = change.After
+		}
+		tl.putTask(change.ID, state)
+	}
+
+	if op.ProjectsChanged {
+		projects := op.ProjectsBefore
+		if forward {
+			projects = op.ProjectsAfter
+		}
+		tl.Projects = append([]Project(nil), projects...)
+		for _, p := range tl.Projects {
+			tl.NextProjectID = max(tl.NextProjectID, p.ID+1)
+		}
+	}
+
+	if op.TrashChanged {
+		trash := op.TrashBefore
+		if forward {
+			trash = op.TrashAfter
+		}
+		tl.Trash = nil
+		for i := range trash {
+			tl.Trash = append(tl.Trash, cloneTask(&trash[i]))
+		}
+	}
+
+	if op.ViewsChanged {
+		views := op.ViewsBefore
+		if forward {
+			views = op.ViewsAfter
+		}
+		tl.Views = append([]View(nil), views...)
+	}
+
+	if op.SortsChanged {
+		sorts := op.SortsBefore
+		if forward {
+			sorts = op.SortsAfter
+		}
+		tl.ListingSorts = maps.Clone(sorts)
+	}
+}
+
+// putTask substitui, insere ou (com t nil) remove a tarefa id, mantendo as
+// tarefas ordenadas por ID
+func (tl *TodoList) putTask(id int, t *Task) {
+	for i := range tl.Tasks {
+		if tl.Tasks[i].ID != id {
+			continue
+		}
+		if t == nil {
+			tl.Tasks = append(tl.Tasks[:i], tl.Tasks[i+1:]...)
+			tl.index.remove(id)
+		} else {
+			tl.Tasks[i] = cloneTask(t)
+			tl.index.add(t)
+		}
+		return
+	}
+	if t == nil {
+		return
+	}
+
+	tl.Tasks = append(tl.Tasks, cloneTask(t))
+	tl.index.add(t)
+	sort.SliceStable(tl.Tasks, func(i, j int) bool { return tl.Tasks[i].ID < tl.Tasks[j].ID })
+	tl.NextID = max(tl.NextID, id+1)
+}
+
+// describe gera uma descrição da operação a partir das alterações
+func (op *Operation) describe() string {
+	if len(op.Changes) == 0 {
+		switch {
+		case op.TrashChanged:
+			return "apagar da lixeira"
+		case op.ProjectsChanged:
+			return "alterar projetos"
+		case op.ViewsChanged:
+			return "alterar visões salvas"
+		default:
+			return "alterar ordenação padrão"
+		}
+	}
+	if len(op.Changes) > 1 {
+		return fmt.Sprintf("alterar %d tarefas", len(op.Changes))
+	}
+
+	change := op.Changes[0]
+	switch {
+	case change.Before == nil && op.TrashChanged:
+		return fmt.Sprintf("restaurar [%d] %s", change.ID, change.After.Title)
+	case change.Before == nil:
+		return fmt.Sprintf("criar [%d] %s", change.ID, change.After.Title)
+	case change.After == nil:
+		return fmt.Sprintf("remover [%d] %s", change.ID, change.Before.Title)
+	case !change.Before.Completed && change.After.Completed:
+		return fmt.Sprintf("concluir [%d] %s", change.ID, change.After.Title)
+	case change.Before.Completed && !change.After.Completed:
+		return fmt.Sprintf("reabrir [%d] %s", change.ID, change.After.Title)
+	default:
+		return fmt.Sprintf("editar [%d] %s", change.ID, change.After.Title)
+	}
+}
+
+// state é uma cópia serializada da lista, usada para comparar versões.
+// A serialização evita copiar à mão cada campo de Task.
+type state struct {
+	tasks    map[int][]byte
+	order    []int
+	projects []byte
+	trash    []byte
+	views    []byte
+	sorts    []byte
+}
+
+// captureState serializa cada tarefa, os projetos, a lixeira, as visões e
+// as ordenações padrão
+func captureState(tl *TodoList) state {
+	s := state{tasks: make(map[int][]byte, len(tl.Tasks))}
+	for _, t := range tl.Tasks {
+		data, _ := json.Marshal(t)
+		s.tasks[t.ID] = data
+		s.order = append(s.order, t.ID)
+	}
+	s.projects, _ = json.Marshal(tl.Projects)
+	s.trash, _ = json.Marshal(tl.Trash)
+	s.views, _ = json.Marshal(tl.Views)
+	s.sorts, _ = json.Marshal(tl.ListingSorts)
+	return s
+}
+
+// diffState monta a operação que leva de before a after
+func diffState(before, after state) (Operation, bool) {
+	var op Operation
+
+	ids := append(append([]int(nil), before.order...), after.order...)
+	sort.Ints(ids)
+	for i, id := range ids {
+		if i > 0 && ids[i-1] == id {
+			continue
+		}
+
+		b, inBefore := before.tasks[id]
+		a, inAfter := after.tasks[id]
+		if inBefore && inAfter && bytes.Equal(a, b) {
+			continue
+		}
+
+		change := TaskChange{ID: id}
+		if inBefore {
+			change.Before = decodeTask(b)
+		}
+		if inAfter {
+			change.After = decodeTask(a)
+		}
+		op.Changes = append(op.Changes, change)
+	}
+
+	if !bytes.Equal(before.projects, after.projects) {
+		op.ProjectsChanged = true
+		json.Unmarshal(before.projects, &op.ProjectsBefore)
+		json.Unmarshal(after.projects, &op.ProjectsAfter)
+	}
+
+	if !bytes.Equal(before.trash, after.trash) {
+		op.TrashChanged = true
+		json.Unmarshal(before.trash, &op.TrashBefore)
+		json.Unmarshal(after.trash, &op.TrashAfter)
+	}
+
+	if !bytes.Equal(before.views, after.views) {
+		op.ViewsChanged = true
+		json.Unmarshal(before.views, &op.ViewsBefore)
+		json.Unmarshal(after.views, &op.ViewsAfter)
+	}
+
+	if !bytes.Equal(before.sorts, after.sorts) {
+		op.SortsChanged = true
+		json.Unmarshal(before.sorts, &op.SortsBefore)
+		json.Unmarshal(after.sorts, &op.SortsAfter)
+	}
+
+	return op, len(op.Changes) > 0 || op.ProjectsChanged || op.TrashChanged || op.ViewsChanged || op.SortsChanged
+}
+
+// decodeTask reconstrói uma tarefa serializada por captureState
+func decodeTask(data []byte) *Task {
+	var t Task
+	json.Unmarshal(data, &t)
+	return &t
+}
+
+// cloneTask copia uma tarefa sem compartilhar slices e ponteiros
+func cloneTask(t *Task) Task {
+	data, _ := json.Marshal(t)
+	return *decodeTask(data)
+}
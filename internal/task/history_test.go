@@ -0,0 +1,210 @@
+package task
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// record registra fn no histórico, falhando o teste se ela falhar
+func record(t *testing.T, h *History, tl *TodoList, fn func() error) {
+	t.Helper()
+	if err := h.Record(tl, "", fn); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestHistoryUndoRedo(t *testing.T) {
+	tl := NewTodoList()
+	h := NewHistory(DefaultHistoryDepth)
+
+	record(t, h, tl, func() error { tl.AddTask("deploy", ""); return nil })
+	record(t, h, tl, func() error { tl.AddTask("backup", ""); return nil })
+	record(t, h, tl, func() error { return tl.EditTask(1, "deploy da API", "") })
+	record(t, h, tl, func() error { return tl.RemoveTask(2) })
+
+	// Cada passo desfeito volta ao estado anterior, do mais recente ao
+	// mais antigo, com a descrição gerada para a operação
+	for _, step := range []struct {
+		label string
+		want  []string
+	}{
+		{"remover [2] backup", []string{"1 deploy da API", "2 backup"}},
+		{"editar [1] deploy da API", []string{"1 deploy", "2 backup"}},
+		{"criar [2] backup", []string{"1 deploy"}},
+		{"criar [1] deploy", nil},
+	} {
+		op, err := h.UndoLast(tl)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if op.Label != step.label || !reflect.DeepEqual(summary(tl), step.want) {
+			t.Errorf("desfazer %q: %q, esperado %q: %q", op.Label, summary(tl), step.label, step.want)
+		}
+	}
+	if _, err := h.UndoLast(tl); !errors.Is(err, ErrNothingToUndo) {
+		t.Errorf("desfazer com o histórico vazio: erro %v", err)
+	}
+
+	for range 4 {
+		if _, err := h.RedoLast(tl); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if want := []string{"1 deploy da API"}; !reflect.DeepEqual(summary(tl), want) {
+		t.Errorf("após refazer tudo: %q, esperado %q", summary(tl), want)
+	}
+	if len(tl.Trash) != 1 || tl.Trash[0].Title != "backup" {
+		t.Errorf("lixeira após refazer a remoção: %v", tl.Trash)
+	}
+	if _, err := h.RedoLast(tl); !errors.Is(err, ErrNothingToRedo) {
+		t.Errorf("refazer sem operações desfeitas: erro %v", err)
+	}
+}
+
+// Desfazer a conclusão de uma tarefa recorrente também apaga a ocorrência
+// criada, e refazer a recria com o mesmo ID
+func TestHistoryToggleRecurring(t *testing.T) {
+	tl := NewTodoList()
+	h := NewHistory(DefaultHistoryDepth)
+	daily := tl.AddTask("regar as plantas", "")
+	due := date(2026, 10, 18)
+	daily.DueDate = &due
+	if err := tl.SetRecurrence(daily.ID, &Recurrence{Every: 1, Unit: UnitDay}); err != nil {
+		t.Fatal(err)
+	}
+
+	record(t, h, tl, func() error { return tl.ToggleTask(1) })
+	if len(tl.Tasks) != 2 || tl.Tasks[1].DueDate == nil || !tl.Tasks[1].DueDate.Equal(date(2026, 10, 19)) {
+		t.Fatalf("próxima ocorrência: %+v", tl.Tasks)
+	}
+
+	op, err := h.UndoLast(tl)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if op.Label != "alterar 2 tarefas" {
+		t.Errorf("operação desfeita: %q", op.Label)
+	}
+	if len(tl.Tasks) != 1 || tl.Tasks[0].Completed || tl.Tasks[0].NextOccurrenceID != 0 {
+		t.Errorf("após desfazer: %+v", tl.Tasks)
+	}
+
+	if _, err := h.RedoLast(tl); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(ids(tl.Tasks), []int{1, 2}) || tl.Tasks[0].NextOccurrenceID != 2 {
+		t.Errorf("após refazer: %+v", tl.Tasks)
+	}
+
+	// Desfazer não devolve IDs: a próxima tarefa não reaproveita o 2
+	if _, err := h.UndoLast(tl); err != nil {
+		t.Fatal(err)
+	}
+	if added := tl.AddTask("nova", ""); added.ID != 3 {
+		t.Errorf("tarefa nova com ID %d, esperado 3", added.ID)
+	}
+}
+
+func TestHistoryNewChangeClearsRedo(t *testing.T) {
+	tl := NewTodoList()
+	h := NewHistory(DefaultHistoryDepth)
+	record(t, h, tl, func() error { tl.AddTask("deploy", ""); return nil })
+	if _, err := h.UndoLast(tl); err != nil {
+		t.Fatal(err)
+	}
+
+	record(t, h, tl, func() error { tl.AddTask("backup", ""); return nil })
+	if len(h.Redo) != 0 {
+		t.Errorf("refazer mantido após uma nova alteração: %v", h.Redo)
+	}
+
+	// Operações que não alteram nada não entram no histórico nem
+	// descartam o refazer
+	if _, err := h.UndoLast(tl); err != nil {
+		t.Fatal(err)
+	}
+	record(t, h, tl, func() error { return nil })
+	if len(h.Undo) != 0 || len(h.Redo) != 1 {
+		t.Errorf("operação vazia registrada: %d para desfazer, %d para refazer", len(h.Undo), len(h.Redo))
+	}
+}
+
+// Uma operação que falha no meio é registrada com o que chegou a alterar,
+// e o erro é repassado
+func TestHistoryRecordsPartialFailure(t *testing.T) {
+	tl := NewTodoList()
+	h := NewHistory(DefaultHistoryDepth)
+	failure := errors.New("falhou")
+
+	err := h.Record(tl, "importar", func() error {
+		tl.AddTask("importada", "")
+		return failure
+	})
+	if !errors.Is(err, failure) {
+		t.Errorf("erro %v, esperado %v", err, failure)
+	}
+	if len(h.Undo) != 1 || h.Undo[0].Label != "importar" {
+		t.Fatalf("histórico: %+v", h.Undo)
+	}
+	if _, err := h.UndoLast(tl); err != nil || len(tl.Tasks) != 0 {
+		t.Errorf("desfazer a alteração parcial: %v, tarefas %v", err, tl.Tasks)
+	}
+}
+
+func TestHistoryDepth(t *testing.T) {
+	tl := NewTodoList()
+	h := NewHistory(3)
+	for _, title := range []string{"um", "dois", "três", "quatro", "cinco"} {
+		record(t, h, tl, func() error { tl.AddTask(title, ""); return nil })
+	}
+	if len(h.Undo) != 3 || h.Undo[0].Label != "criar [3] três" {
+		t.Fatalf("guardadas %d operações, a mais antiga %q", len(h.Undo), h.Undo[0].Label)
+	}
+
+	h.SetDepth(1)
+	if len(h.Undo) != 1 || h.Undo[0].Label != "criar [5] cinco" {
+		t.Errorf("após SetDepth(1): %d operações", len(h.Undo))
+	}
+
+	// Profundidade zero desativa o histórico, mas a alteração acontece
+	h.SetDepth(0)
+	record(t, h, tl, func() error { tl.AddTask("seis", ""); return nil })
+	if len(h.Undo) != 0 || len(tl.Tasks) != 6 {
+		t.Errorf("histórico desativado: %d operações, %d tarefas", len(h.Undo), len(tl.Tasks))
+	}
+}
+
+// O histórico é gravado em JSON entre execuções (veja storage.HistoryStore)
+// e continua desfazendo e refazendo depois de relido
+func TestHistoryJSON(t *testing.T) {
+	tl := NewTodoList()
+	h := NewHistory(DefaultHistoryDepth)
+	record(t, h, tl, func() error { tl.AddTask("deploy #infra", ""); return nil })
+	record(t, h, tl, func() error { return tl.ToggleTask(1) })
+	if _, err := h.UndoLast(tl); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := json.Marshal(h)
+	if err != nil {
+		t.Fatal(err)
+	}
+	loaded := NewHistory(DefaultHistoryDepth)
+	if err := json.Unmarshal(data, loaded); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := loaded.RedoLast(tl); err != nil || !tl.Tasks[0].Completed {
+		t.Errorf("refazer após reler: %v, concluída %v", err, tl.Tasks[0].Completed)
+	}
+	for range 2 {
+		if _, err := loaded.UndoLast(tl); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(tl.Tasks) != 0 {
+		t.Errorf("tarefas após desfazer tudo: %v", tl.Tasks)
+	}
+}
@@ -0,0 +1,185 @@
+package task
+
+import (
+	"strings"
+)
+
+// searchIndex é um índice invertido das palavras (sem acento e em
+// minúsculas) do título e da descrição das tarefas da lista. As buscas o
+// usam para descartar, sem avaliar, as tarefas que não têm nenhuma palavra
+// capaz de casar com os termos de texto. Depois de ligado com
+// EnableSearchIndex, é mantido a cada inclusão, edição e remoção de tarefa.
+//
+// O índice fica só em memória e não é gravado: montá-lo custa mais que uma
+// busca sem ele (cerca de 300ms contra 150ms com 50 mil tarefas; veja os
+// benchmarks em index_test.go), então só compensa em sessões com várias
+// buscas, como o menu. Comandos avulsos ("todo search") avaliam todas as
+// tarefas.
+type searchIndex struct {
+	words map[string]*posting // palavra → tarefas que a contêm
+	docs  map[int]indexedDoc  // ID → texto indexado
+}
+
+// posting guarda as runas de uma palavra do índice e as tarefas onde ela
+// aparece
+type posting struct {
+	runes []rune
+	ids   map[int]struct{}
+}
+
+// indexedDoc é o texto de uma tarefa no momento em que foi indexada
+type indexedDoc struct {
+	title, description string
+	words              []string
+}
+
+// EnableSearchIndex monta o índice de busca da lista, se ainda não existir,
+// para que as próximas buscas o usem
+func (tl *TodoList) EnableSearchIndex() {
+	tl.searchIndex()
+}
+
+// searchIndex retorna o índice da lista, montando-o se preciso
+func (tl *TodoList) searchIndex() *searchIndex {
+	if tl.index == nil {
+		tl.index = &searchIndex{
+			words: make(map[string]*posting),
+			docs:  make(map[int]indexedDoc, len(tl.Tasks)),
+		}
+		for i := range tl.Tasks {
+			tl.index.add(&tl.Tasks[i])
+		}
+	}
+	return tl.index
+}
+
+// add indexa a tarefa, substituindo a versão anterior. Não faz nada se o
+// índice ainda não foi montado.
+func (idx *searchIndex) add(t *Task) {
+	if idx == nil {
+		return
+	}
+	idx.remove(t.ID)
+
+	words := splitWords(foldRunes(t.Title + " " + t.Description))
+	doc := indexedDoc{title: t.Title, description: t.Description}
+	for _, word := range words {
+		key := string(word)
+		p, ok := idx.words[key]
+		if !ok {
+			p = &posting{runes: word, ids: make(map[int]struct{})}
+			idx.words[key] = p
+		}
+		if _, seen := p.ids[t.ID]; !seen {
+			p.ids[t.ID] = struct{}{}
+			doc.words = append(doc.words, key)
+		}
+	}
+	idx.docs[t.ID] = doc
+}
+
+// remove tira a tarefa do índice
+func (idx *searchIndex) remove(id int) {
+	if idx == nil {
+		return
+	}
+	doc, ok := idx.docs[id]
+	if !ok {
+		return
+	}
+	for _, key := range doc.words {
+		p := idx.words[key]
+		delete(p.ids, id)
+		if len(p.ids) == 0 {
+			delete(idx.words, key)
+		}
+	}
+	delete(idx.docs, id)
+}
+
+// current informa se a tarefa está indexada com o título e a descrição
+// atuais. Tarefas que não estão (como as da lixeira) são avaliadas sem
+// ajuda do índice.
+func (idx *searchIndex) current(t *Task) bool {
+	doc, ok := idx.docs[t.ID]
+	return ok && doc.title == t.Title && doc.description == t.Description
+}
+
+// lookup retorna as tarefas com palavras que podem casar com o termo de
+// texto: todas as que FuzzyMatch aceitaria estão entre elas. O segundo
+// retorno é false se o termo não tem palavras e não pode ser restringido.
+func (idx *searchIndex) lookup(term string) (map[int]struct{}, bool) {
+	pattern := foldRunes(term)
+	parts := splitWords(pattern)
+	if len(parts) == 0 {
+		return nil, false
+	}
+
+	// Um termo de uma palavra pode casar por subsequência ou com erros de
+	// digitação; com várias, cada uma precisa aparecer inteira em alguma
+	// palavra da tarefa
+	single := len(parts) == 1 && isWord(pattern)
+	var ids map[int]struct{}
+	for _, part := range parts {
+		allowed := typoAllowance(part)
+		matched := make(map[int]struct{})
+		for key, p := range idx.words {
+			if single && !wordMayMatch(part, p.runes, allowed) {
+				continue
+			}
+			if !single && !strings.Contains(key, string(part)) {
+				continue
+			}
+			for id := range p.ids {
+				if ids == nil || hasID(ids, id) {
+					matched[id] = struct{}{}
+				}
+			}
+		}
+		ids = matched
+	}
+	return ids, true
+}
+
+// wordMayMatch informa se FuzzyMatch aceitaria o termo (de uma palavra)
+// nesta palavra: como trecho, como subsequência a partir do início ou com
+// até allowed erros de digitação
+func wordMayMatch(pattern, word []rune, allowed int) bool {
+	if strings.Contains(string(word), string(pattern)) {
+		return true
+	}
+	if len(pattern) >= 2 && word[0] == pattern[0] {
+		if _, ok := wordSubsequence(pattern, word, 0); ok {
+			return true
+		}
+	}
+	if allowed == 0 {
+		return false
+	}
+	_, distance := typoDistance(pattern, word, allowed)
+	return distance <= allowed
+}
+
+// splitWords divide runas já normalizadas em palavras
+func splitWords(runes []rune) [][]rune {
+	var words [][]rune
+	for i := 0; i < len(runes); {
+		if !isWordRune(runes[i]) {
+			i++
+			continue
+		}
+		end := i
+		for end < len(runes) && isWordRune(runes[end]) {
+			end++
+		}
+		words = append(words, runes[i:end])
+		i = end
+	}
+	return words
+}
+
+// hasID informa se o conjunto contém o ID
+func hasID(ids map[int]struct{}, id int) bool {
+	_, ok := ids[id]
+	return ok
+}
@@ -0,0 +1,118 @@
+package task
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// benchmarkTasks é o tamanho da lista usada nos benchmarks de busca
+const benchmarkTasks = 50000
+
+// benchmarkWords compõem os títulos e descrições gerados
+var benchmarkWords = []string{
+	"deploy", "revisar", "migração", "relatório", "reunião", "cliente",
+	"servidor", "backup", "contrato", "orçamento", "testes", "documentação",
+	"infra", "pagamento", "fatura", "suporte", "release", "banco",
+}
+
+// newBenchmarkList gera uma lista com n tarefas de textos variados e um
+// termo raro, que aparece em poucas delas
+func newBenchmarkList(n int) *TodoList {
+	tl := NewTodoList()
+	for i := 0; i < n; i++ {
+		title := fmt.Sprintf("%s %s %d", benchmarkWords[i%len(benchmarkWords)],
+			benchmarkWords[(i/7)%len(benchmarkWords)], i)
+		description := fmt.Sprintf("%s do %s", benchmarkWords[(i/3)%len(benchmarkWords)],
+			benchmarkWords[(i/11)%len(benchmarkWords)])
+		if i%1000 == 0 {
+			description += " kubernetes"
+		}
+		// Montadas diretamente, sem o custo de AddTask, que não é medido aqui
+		tl.Tasks = append(tl.Tasks, Task{ID: tl.NextID, Title: title, Description: description, CreatedAt: time.Now()})
+		tl.NextID++
+	}
+	return tl
+}
+
+// benchmarkQueries são buscas seletivas: trecho, erro de digitação e
+// subsequência de uma palavra rara
+var benchmarkQueries = []string{"kubernetes", "kubernets", "kbrnts"}
+
+func BenchmarkSearchIndexed(b *testing.B) {
+	tl := newBenchmarkList(benchmarkTasks)
+	now := time.Now()
+	tl.EnableSearchIndex() // o índice é montado fora da medição
+
+	for _, source := range benchmarkQueries {
+		query, err := ParseQuery(source, now)
+		if err != nil {
+			b.Fatal(err)
+		}
+		b.Run(source, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if len(query.Filter(tl, tl.Tasks, now)) == 0 {
+					b.Fatal("nenhuma tarefa encontrada")
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkSearchLinear(b *testing.B) {
+	tl := newBenchmarkList(benchmarkTasks)
+	now := time.Now()
+
+	for _, source := range benchmarkQueries {
+		query, err := ParseQuery(source, now)
+		if err != nil {
+			b.Fatal(err)
+		}
+		b.Run(source, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if len(query.Filter(tl, tl.Tasks, now)) == 0 {
+					b.Fatal("nenhuma tarefa encontrada")
+				}
+			}
+		})
+	}
+}
+
+// BenchmarkSearchIndexBuild mede a montagem do índice, paga uma vez por
+// sessão do menu (veja EnableSearchIndex)
+func BenchmarkSearchIndexBuild(b *testing.B) {
+	tl := newBenchmarkList(benchmarkTasks)
+
+	for i := 0; i < b.N; i++ {
+		tl.index = nil
+		tl.searchIndex()
+	}
+}
+
+func TestSearchIndexMatchesLinearScan(t *testing.T) {
+	tl := newBenchmarkList(2000)
+	tl.AddTask("Ação do CONSELHO", "")
+	edited := tl.AddTask("rascunho", "")
+	now := time.Now()
+	tl.EnableSearchIndex()
+	edited.Title = "deplyo urgente" // texto desatualizado no índice
+
+	for _, source := range []string{"kubernetes", "kubernets", "kbrnts", "acao", "deploy", "rel 12", "deploy OR fatura", "-backup"} {
+		query, err := ParseQuery(source, now)
+		if err != nil {
+			t.Fatalf("%q: %v", source, err)
+		}
+		indexed := query.Filter(tl, tl.Tasks, now)
+
+		var linear []Task
+		for i := range tl.Tasks {
+			if query.Match(tl, &tl.Tasks[i], now) {
+				linear = append(linear, tl.Tasks[i])
+			}
+		}
+		if !reflect.DeepEqual(ids(indexed), ids(linear)) {
+			t.Errorf("%q: índice encontrou %v, busca linear %v", source, ids(indexed), ids(linear))
+		}
+	}
+}
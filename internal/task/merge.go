@@ -0,0 +1,327 @@
+package task
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Merge combina duas versões de uma lista que divergiram de uma mesma base,
+// como quando dois terminais alteram o mesmo arquivo ao mesmo tempo.
+// Alterações feitas em apenas um dos lados são mantidas; quando os dois
+// lados alteram a mesma tarefa, vence ours e o ID é devolvido em conflicts.
+// Itens criados em ours com um ID que theirs também usou recebem um novo ID.
+func Merge(base, ours, theirs *TodoList) (merged *TodoList, conflicts []int) {
+	merged = &TodoList{
+		NextID:        max(ours.NextID, theirs.NextID),
+		NextProjectID: max(ours.NextProjectID, theirs.NextProjectID),
+	}
+
+	projectRemap := mergeProjects(merged, base.Projects, ours.Projects, theirs.Projects)
+	ours = renumberTasks(merged, ours, base, theirs, projectRemap)
+
+	baseTasks := indexTasks(base.Tasks)
+	oursTasks := indexTasks(ours.Tasks)
+	theirsTasks := indexTasks(theirs.Tasks)
+
+	for _, id := range unionKeys(baseTasks, oursTasks, theirsTasks) {
+		b, inBase := baseTasks[id]
+		o, inOurs := oursTasks[id]
+		t, inTheirs := theirsTasks[id]
+
+		switch {
+		case !inBase && inOurs:
+			merged.Tasks = append(merged.Tasks, o)
+		case !inBase && inTheirs:
+			merged.Tasks = append(merged.Tasks, t)
+		case !inOurs && !inTheirs:
+			// removida nos dois lados
+		case !inOurs:
+			// removida em ours: mantém apenas se theirs alterou a tarefa
+			if !reflect.DeepEqual(b, t) {
+				merged.Tasks = append(merged.Tasks, t)
+			}
+		case !inTheirs:
+			if !reflect.DeepEqual(b, o) {
+				merged.Tasks = append(merged.Tasks, o)
+			}
+		case reflect.DeepEqual(b, o):
+			merged.Tasks = append(merged.Tasks, t)
+		case reflect.DeepEqual(b, t) || reflect.DeepEqual(o, t):
+			merged.Tasks = append(merged.Tasks, o)
+		default:
+			merged.Tasks = append(merged.Tasks, o)
+			conflicts = append(conflicts, id)
+		}
+	}
+
+	sort.Slice(merged.Tasks, func(i, j int) bool { return merged.Tasks[i].ID < merged.Tasks[j].ID })
+	merged.Trash = mergeTrash(merged, base.Trash, ours.Trash, theirs.Trash)
+	merged.Views = mergeViews(base.Views, ours.Views, theirs.Views)
+	merged.ListingSorts = mergeListingSorts(base.ListingSorts, ours.ListingSorts, theirs.ListingSorts)
+	merged.dropDanglingReferences()
+	return merged, conflicts
+}
+
+// mergeTrash combina as lixeiras dos dois lados. Itens apagados
+// definitivamente em um dos lados não voltam, e tarefas que continuam na
+// lista após o merge saem da lixeira.
+func mergeTrash(merged *TodoList, base, ours, theirs []Task) []Task {
+	baseTrash := indexTasks(base)
+	oursTrash := indexTasks(ours)
+	theirsTrash := indexTasks(theirs)
+	present := indexTasks(merged.Tasks)
+
+	var trash []Task
+	for _, id := range unionKeys(oursTrash, theirsTrash) {
+		if _, ok := present[id]; ok {
+			continue
+		}
+		o, inOurs := oursTrash[id]
+		t, inTheirs := theirsTrash[id]
+		if _, inBase := baseTrash[id]; inBase && (!inOurs || !inTheirs) {
+			continue
+		}
+		if inOurs {
+			trash = append(trash, o)
+		} else {
+			trash = append(trash, t)
+		}
+	}
+	return trash
+}
+
+// mergeViews combina as visões dos dois lados, identificadas pelo nome.
+// Visões apagadas em um lado e intocadas no outro não voltam; quando os
+// dois lados alteram a mesma visão, vence ours.
+func mergeViews(base, ours, theirs []View) []View {
+	baseViews := indexViews(base)
+	oursViews := indexViews(ours)
+	theirsViews := indexViews(theirs)
+
+	names := make(map[string]bool)
+	for _, views := range []map[string]View{oursViews, theirsViews} {
+		for name := range views {
+			names[name] = true
+		}
+	}
+
+	var merged []View
+	for name := range names {
+		b, inBase := baseViews[name]
+		o, inOurs := oursViews[name]
+		t, inTheirs := theirsViews[name]
+
+		switch {
+		case inOurs && inTheirs:
+			if reflect.DeepEqual(b, o) {
+				merged = append(merged, t)
+			} else {
+				merged = append(merged, o)
+			}
+		case inOurs:
+			if !inBase || !reflect.DeepEqual(b, o) {
+				merged = append(merged, o)
+			}
+		case inTheirs:
+			if !inBase || !reflect.DeepEqual(b, t) {
+				merged = append(merged, t)
+			}
+		}
+	}
+	sort.Slice(merged, func(i, j int) bool { return merged[i].CreatedAt.Before(merged[j].CreatedAt) })
+	return merged
+}
+
+// mergeListingSorts combina as ordenações padrão das listagens. Vale a de
+// theirs para as listagens que ours não alterou.
+func mergeListingSorts(base, ours, theirs map[string]string) map[string]string {
+	var merged map[string]string
+	for _, listing := range Listings {
+		spec := theirs[listing]
+		if ours[listing] != base[listing] {
+			spec = ours[listing]
+		}
+		if spec != "" {
+			if merged == nil {
+				merged = make(map[string]string)
+			}
+			merged[listing] = spec
+		}
+	}
+	return merged
+}
+
+// mergeProjects combina os projetos dos dois lados em merged e retorna o
+// novo ID dos projetos de ours que colidiram com projetos de theirs
+func mergeProjects(merged *TodoList, base, ours, theirs []Project) map[int]int {
+	baseProjects := indexProjects(base)
+	theirsProjects := indexProjects(theirs)
+	remap := make(map[int]int)
+
+	byID := make(map[int]Project)
+	for _, p := range theirs {
+		byID[p.ID] = p
+	}
+	for _, p := range ours {
+		b, inBase := baseProjects[p.ID]
+		t, inTheirs := theirsProjects[p.ID]
+
+		switch {
+		case !inTheirs:
+			byID[p.ID] = p
+		case inBase:
+			if !reflect.DeepEqual(b, p) {
+				byID[p.ID] = p
+			}
+		case !reflect.DeepEqual(t, p):
+			// os dois lados criaram um projeto com o mesmo ID
+			if existing := findProjectByName(theirs, p.Name); existing != nil {
+				remap[p.ID] = existing.ID
+				continue
+			}
+			remap[p.ID] = merged.NextProjectID
+			p.ID = merged.NextProjectID
+			merged.NextProjectID++
+			byID[p.ID] = p
+		}
+	}
+
+	for _, p := range byID {
+		merged.Projects = append(merged.Projects, p)
+	}
+	sort.Slice(merged.Projects, func(i, j int) bool { return merged.Projects[i].ID < merged.Projects[j].ID })
+	return remap
+}
+
+// renumberTasks devolve uma cópia de ours em que as tarefas novas cujo ID
+// também foi usado por uma tarefa nova de theirs recebem IDs livres. As
+// referências entre tarefas de ours e os projetos remapeados acompanham.
+// As tarefas da lixeira também são consideradas.
+func renumberTasks(merged, ours, base, theirs *TodoList, projectRemap map[int]int) *TodoList {
+	baseTasks := indexTasks(append(append([]Task(nil), base.Tasks...), base.Trash...))
+	theirsTasks := indexTasks(append(append([]Task(nil), theirs.Tasks...), theirs.Trash...))
+
+	remap := make(map[int]int)
+	for _, t := range append(append([]Task(nil), ours.Tasks...), ours.Trash...) {
+		if _, inBase := baseTasks[t.ID]; inBase {
+			continue
+		}
+		if other, inTheirs := theirsTasks[t.ID]; inTheirs && !reflect.DeepEqual(other, t) {
+			remap[t.ID] = merged.NextID
+			merged.NextID++
+		}
+	}
+	if len(remap) == 0 && len(projectRemap) == 0 {
+		return ours
+	}
+
+	mapID := func(id int) int {
+		if newID, ok := remap[id]; ok {
+			return newID
+		}
+		return id
+	}
+
+	renumber := func(t Task) Task {
+		t.ID = mapID(t.ID)
+		t.ParentID = mapID(t.ParentID)
+		t.SeriesID = mapID(t.SeriesID)
+		t.NextOccurrenceID = mapID(t.NextOccurrenceID)
+		if len(t.BlockedBy) > 0 {
+			blockedBy := make([]int, len(t.BlockedBy))
+			for j, id := range t.BlockedBy {
+				blockedBy[j] = mapID(id)
+			}
+			sort.Ints(blockedBy)
+			t.BlockedBy = blockedBy
+		}
+		if newID, ok := projectRemap[t.ProjectID]; ok {
+			t.ProjectID = newID
+		}
+		return t
+	}
+
+	renumbered := &TodoList{Tasks: make([]Task, len(ours.Tasks))}
+	for i, t := range ours.Tasks {
+		renumbered.Tasks[i] = renumber(t)
+	}
+	for _, t := range ours.Trash {
+		renumbered.Trash = append(renumbered.Trash, renumber(t))
+	}
+	return renumbered
+}
+
+// dropDanglingReferences remove referências a tarefas que não existem mais
+// após o merge: dependências são descartadas e subtarefas órfãs viram raiz
+func (tl *TodoList) dropDanglingReferences() {
+	present := indexTasks(tl.Tasks)
+	for i := range tl.Tasks {
+		t := &tl.Tasks[i]
+		if _, ok := present[t.ParentID]; t.ParentID != 0 && !ok {
+			t.ParentID = 0
+		}
+
+		var blockedBy []int
+		for _, id := range t.BlockedBy {
+			if _, ok := present[id]; ok {
+				blockedBy = append(blockedBy, id)
+			}
+		}
+		t.BlockedBy = blockedBy
+	}
+}
+
+// indexTasks indexa as tarefas por ID
+func indexTasks(tasks []Task) map[int]Task {
+	index := make(map[int]Task, len(tasks))
+	for _, t := range tasks {
+		index[t.ID] = t
+	}
+	return index
+}
+
+// indexProjects indexa os projetos por ID
+func indexProjects(projects []Project) map[int]Project {
+	index := make(map[int]Project, len(projects))
+	for _, p := range projects {
+		index[p.ID] = p
+	}
+	return index
+}
+
+// indexViews indexa as visões pelo nome em minúsculas
+func indexViews(views []View) map[string]View {
+	index := make(map[string]View, len(views))
+	for _, v := range views {
+		index[strings.ToLower(v.Name)] = v
+	}
+	return index
+}
+
+// findProjectByName busca um projeto pelo nome, sem diferenciar maiúsculas
+func findProjectByName(projects []Project, name string) *Project {
+	for i := range projects {
+		if strings.EqualFold(projects[i].Name, name) {
+			return &projects[i]
+		}
+	}
+	return nil
+}
+
+// unionKeys retorna, em ordem crescente, os IDs presentes em qualquer um
+// dos índices
+func unionKeys(indexes ...map[int]Task) []int {
+	seen := make(map[int]bool)
+	var keys []int
+	for _, index := range indexes {
+		for id := range index {
+			if !seen[id] {
+				seen[id] = true
+				keys = append(keys, id)
+			}
+		}
+	}
+	sort.Ints(keys)
+	return keys
+}
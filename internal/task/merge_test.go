@@ -0,0 +1,187 @@
+package task
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+// diverge monta uma base com as tarefas informadas e duas cópias dela,
+// como dois terminais que carregaram o mesmo arquivo. As cópias passam
+// pelo JSON, como no storage, para que base e lados comecem idênticos.
+func diverge(t *testing.T, titles ...string) (base, ours, theirs *TodoList) {
+	t.Helper()
+	tl := NewTodoList()
+	for _, title := range titles {
+		tl.AddTask(title, "")
+	}
+	data, err := json.Marshal(tl)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lists := make([]*TodoList, 3)
+	for i := range lists {
+		lists[i] = &TodoList{}
+		if err := json.Unmarshal(data, lists[i]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return lists[0], lists[1], lists[2]
+}
+
+// summary resume as tarefas como "ID título", em ordem de ID
+func summary(tl *TodoList) []string {
+	var lines []string
+	for _, t := range tl.Tasks {
+		lines = append(lines, fmt.Sprintf("%d %s", t.ID, t.Title))
+	}
+	return lines
+}
+
+// rename muda o título de uma tarefa, falhando o teste se ela não existir
+func rename(t *testing.T, tl *TodoList, id int, title string) {
+	t.Helper()
+	task, err := tl.GetTask(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	task.Title = title
+}
+
+func TestMergeKeepsChangesFromEachSide(t *testing.T) {
+	base, ours, theirs := diverge(t, "deploy", "backup", "relatório")
+	rename(t, ours, 1, "deploy da API")
+	if err := theirs.ToggleTask(2); err != nil {
+		t.Fatal(err)
+	}
+
+	merged, conflicts := Merge(base, ours, theirs)
+	if conflicts != nil {
+		t.Errorf("conflitos inesperados: %v", conflicts)
+	}
+	if want := []string{"1 deploy da API", "2 backup", "3 relatório"}; !reflect.DeepEqual(summary(merged), want) {
+		t.Errorf("merge = %q, esperado %q", summary(merged), want)
+	}
+	if !merged.Tasks[1].Completed {
+		t.Error("a conclusão feita em theirs se perdeu")
+	}
+}
+
+func TestMergeSameTaskChangedOnBothSides(t *testing.T) {
+	base, ours, theirs := diverge(t, "deploy")
+	rename(t, ours, 1, "deploy hoje")
+	rename(t, theirs, 1, "deploy amanhã")
+
+	merged, conflicts := Merge(base, ours, theirs)
+	if !reflect.DeepEqual(conflicts, []int{1}) {
+		t.Errorf("conflitos = %v, esperado [1]", conflicts)
+	}
+	if merged.Tasks[0].Title != "deploy hoje" {
+		t.Errorf("no conflito venceu %q, esperado a versão de ours", merged.Tasks[0].Title)
+	}
+
+	// A mesma alteração nos dois lados não é conflito
+	base, ours, theirs = diverge(t, "deploy")
+	rename(t, ours, 1, "deploy hoje")
+	rename(t, theirs, 1, "deploy hoje")
+	if _, conflicts := Merge(base, ours, theirs); conflicts != nil {
+		t.Errorf("alterações idênticas geraram conflitos: %v", conflicts)
+	}
+}
+
+func TestMergeRenumbersTasksCreatedOnBothSides(t *testing.T) {
+	base, ours, theirs := diverge(t, "deploy")
+	theirs.AddTask("deles", "")
+	parent := ours.AddTask("nossa", "")
+	if _, err := ours.AddSubtask(parent.ID, "filha", ""); err != nil {
+		t.Fatal(err)
+	}
+	if err := ours.AddDependency(1, parent.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	merged, conflicts := Merge(base, ours, theirs)
+	if conflicts != nil {
+		t.Errorf("conflitos inesperados: %v", conflicts)
+	}
+	// A tarefa 2 de ours ganha um ID livre, e a subtarefa e a dependência
+	// acompanham a renumeração
+	if want := []string{"1 deploy", "2 deles", "3 filha", "4 nossa"}; !reflect.DeepEqual(summary(merged), want) {
+		t.Fatalf("merge = %q, esperado %q", summary(merged), want)
+	}
+	if parentID := merged.Tasks[2].ParentID; parentID != 4 {
+		t.Errorf("subtarefa aponta para %d, esperado 4", parentID)
+	}
+	if blockedBy := merged.Tasks[0].BlockedBy; !reflect.DeepEqual(blockedBy, []int{4}) {
+		t.Errorf("dependências = %v, esperado [4]", blockedBy)
+	}
+	if merged.NextID != 5 {
+		t.Errorf("NextID = %d, esperado 5", merged.NextID)
+	}
+}
+
+func TestMergeRemovals(t *testing.T) {
+	// Removida em um lado e intocada no outro: continua removida
+	base, ours, theirs := diverge(t, "deploy", "backup")
+	if err := ours.RemoveTask(2); err != nil {
+		t.Fatal(err)
+	}
+	merged, _ := Merge(base, ours, theirs)
+	if want := []string{"1 deploy"}; !reflect.DeepEqual(summary(merged), want) {
+		t.Errorf("merge = %q, esperado %q", summary(merged), want)
+	}
+
+	// Removida em um lado e alterada no outro: a alteração prevalece
+	base, ours, theirs = diverge(t, "deploy", "backup")
+	if err := ours.RemoveTask(2); err != nil {
+		t.Fatal(err)
+	}
+	rename(t, theirs, 2, "backup semanal")
+	merged, _ = Merge(base, ours, theirs)
+	if want := []string{"1 deploy", "2 backup semanal"}; !reflect.DeepEqual(summary(merged), want) {
+		t.Errorf("merge = %q, esperado %q", summary(merged), want)
+	}
+}
+
+func TestMergeDropsDanglingDependencies(t *testing.T) {
+	base, ours, theirs := diverge(t, "deploy", "backup")
+	if err := ours.AddDependency(1, 2); err != nil {
+		t.Fatal(err)
+	}
+	theirs.Tasks = theirs.Tasks[:1] // theirs apagou a bloqueadora
+
+	merged, _ := Merge(base, ours, theirs)
+	if want := []string{"1 deploy"}; !reflect.DeepEqual(summary(merged), want) {
+		t.Fatalf("merge = %q, esperado %q", summary(merged), want)
+	}
+	if blockedBy := merged.Tasks[0].BlockedBy; len(blockedBy) > 0 {
+		t.Errorf("dependência de tarefa inexistente mantida: %v", blockedBy)
+	}
+}
+
+func TestMergeProjectsCreatedOnBothSides(t *testing.T) {
+	base, ours, theirs := diverge(t, "deploy")
+	if _, err := theirs.AddProject("casa"); err != nil {
+		t.Fatal(err)
+	}
+	work, err := ours.AddProject("trabalho")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ours.Tasks[0].ProjectID = work.ID
+
+	merged, _ := Merge(base, ours, theirs)
+	names := make(map[int]string)
+	for _, p := range merged.Projects {
+		names[p.ID] = p.Name
+	}
+	// "trabalho" colidiu com o ID de "casa" e foi renumerado, levando junto
+	// a tarefa
+	if want := map[int]string{1: "casa", 2: "trabalho"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("projetos = %v, esperado %v", names, want)
+	}
+	if got := merged.Tasks[0].ProjectID; got != 2 {
+		t.Errorf("tarefa ficou no projeto %d, esperado 2", got)
+	}
+}
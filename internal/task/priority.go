@@ -0,0 +1,111 @@
+package task
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Priority representa a importância de uma tarefa
+type Priority int
+
+// Níveis de prioridade, do menos para o mais importante
+const (
+	PriorityNone Priority = iota
+	PriorityLow
+	PriorityMedium
+	PriorityHigh
+	PriorityUrgent
+)
+
+// priorityNames guarda o nome estável (usado em JSON e flags) de cada nível
+var priorityNames = map[Priority]string{
+	PriorityNone:   "none",
+	PriorityLow:    "low",
+	PriorityMedium: "medium",
+	PriorityHigh:   "high",
+	PriorityUrgent: "urgent",
+}
+
+// priorityAliases aceita nomes em português além dos nomes estáveis
+var priorityAliases = map[string]Priority{
+	"nenhuma": PriorityNone,
+	"baixa":   PriorityLow,
+	"media":   PriorityMedium,
+	"média":   PriorityMedium,
+	"alta":    PriorityHigh,
+	"urgente": PriorityUrgent,
+}
+
+// String retorna o nome estável da prioridade
+func (p Priority) String() string {
+	if name, ok := priorityNames[p]; ok {
+		return name
+	}
+	return fmt.Sprintf("Priority(%d)", int(p))
+}
+
+// Label retorna o nome da prioridade para exibição ao usuário
+func (p Priority) Label() string {
+	switch p {
+	case PriorityLow:
+		return "🟢 Baixa"
+	case PriorityMedium:
+		return "🟡 Média"
+	case PriorityHigh:
+		return "🟠 Alta"
+	case PriorityUrgent:
+		return "🔴 Urgente"
+	default:
+		return "Nenhuma"
+	}
+}
+
+// Valid informa se a prioridade é um dos níveis conhecidos
+func (p Priority) Valid() bool {
+	_, ok := priorityNames[p]
+	return ok
+}
+
+// ParsePriority converte um nome (em inglês ou português) ou número de 0 a 4
+// em Priority. Texto vazio equivale a PriorityNone.
+func ParsePriority(s string) (Priority, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if s == "" {
+		return PriorityNone, nil
+	}
+
+	for p, name := range priorityNames {
+		if s == name || s == fmt.Sprint(int(p)) {
+			return p, nil
+		}
+	}
+	if p, ok := priorityAliases[s]; ok {
+		return p, nil
+	}
+
+	return PriorityNone, fmt.Errorf("prioridade inválida: %s (use none, low, medium, high ou urgent)", s)
+}
+
+// MarshalText serializa a prioridade pelo nome estável
+func (p Priority) MarshalText() ([]byte, error) {
+	if !p.Valid() {
+		return nil, fmt.Errorf("prioridade inválida: %d", int(p))
+	}
+	return []byte(p.String()), nil
+}
+
+// UnmarshalText lê a prioridade a partir do nome estável
+func (p *Priority) UnmarshalText(text []byte) error {
+	parsed, err := ParsePriority(string(text))
+	if err != nil {
+		return err
+	}
+	*p = parsed
+	return nil
+}
+
+// SortByPriority ordena as tarefas da mais para a menos importante;
+// em caso de empate, vale a ordem manual (veja DefaultSort)
+func SortByPriority(tasks []Task) {
+	DefaultSort.Sort(tasks)
+}
@@ -0,0 +1,260 @@
+package task
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// DefaultProjectID identifica o projeto padrão, ao qual pertencem as tarefas
+// sem projeto (inclusive as de arquivos anteriores à criação de projetos)
+const DefaultProjectID = 0
+
+// DefaultProjectName é o nome do projeto padrão
+const DefaultProjectName = "geral"
+
+// ErrProjectNotFound indica que não existe projeto com o nome informado
+var ErrProjectNotFound = errors.New("projeto não encontrado")
+
+// Project agrupa tarefas relacionadas, como "trabalho" ou "casa"
+type Project struct {
+	ID        int       `json:"id"`
+	Name      string    `json:"name"`
+	Archived  bool      `json:"archived,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ProjectStat reúne as estatísticas de um projeto
+type ProjectStat struct {
+	Project   Project
+	Total     int
+	Completed int
+	Pending   int
+}
+
+// defaultProject retorna o projeto padrão implícito
+func defaultProject() Project {
+	return Project{ID: DefaultProjectID, Name: DefaultProjectName}
+}
+
+// AddProject cria um novo projeto
+func (tl *TodoList) AddProject(name string) (*Project, error) {
+	name, err := tl.validateProjectName(name)
+	if err != nil {
+		return nil, err
+	}
+
+	// Arquivos antigos não têm o contador de projetos
+	if tl.NextProjectID <= DefaultProjectID {
+		tl.NextProjectID = DefaultProjectID + 1
+	}
+
+	tl.Projects = append(tl.Projects, Project{
+		ID:        tl.NextProjectID,
+		Name:      name,
+		CreatedAt: time.Now(),
+	})
+	tl.NextProjectID++
+
+	return &tl.Projects[len(tl.Projects)-1], nil
+}
+
+// RenameProject altera o nome de um projeto
+func (tl *TodoList) RenameProject(name, newName string) error {
+	project, err := tl.findProject(name)
+	if err != nil {
+		return err
+	}
+
+	newName, err = tl.validateProjectName(newName)
+	if err != nil && !strings.EqualFold(newName, project.Name) {
+		return err
+	}
+
+	project.Name = strings.TrimSpace(newName)
+	return nil
+}
+
+// SetProjectArchived arquiva ou desarquiva um projeto. Tarefas de projetos
+// arquivados deixam de aparecer na visão geral, mas continuam salvas.
+func (tl *TodoList) SetProjectArchived(name string, archived bool) error {
+	project, err := tl.findProject(name)
+	if err != nil {
+		return err
+	}
+
+	project.Archived = archived
+	return nil
+}
+
+// FindProject retorna um projeto pelo nome, sem diferenciar maiúsculas.
+// O nome do projeto padrão também é aceito.
+func (tl *TodoList) FindProject(name string) (Project, error) {
+	if strings.EqualFold(strings.TrimSpace(name), DefaultProjectName) {
+		return defaultProject(), nil
+	}
+
+	project, err := tl.findProject(name)
+	if err != nil {
+		return Project{}, err
+	}
+	return *project, nil
+}
+
+// ProjectName retorna o nome do projeto com o ID informado
+func (tl *TodoList) ProjectName(id int) string {
+	for _, project := range tl.Projects {
+		if project.ID == id {
+			return project.Name
+		}
+	}
+	return DefaultProjectName
+}
+
+// ListProjects retorna os projetos, começando pelo padrão e seguido dos
+// demais em ordem alfabética. Projetos arquivados só são incluídos se
+// includeArchived for verdadeiro.
+func (tl *TodoList) ListProjects(includeArchived bool) []Project {
+	var projects []Project
+	for _, project := range tl.Projects {
+		if project.Archived && !includeArchived {
+			continue
+		}
+		projects = append(projects, project)
+	}
+	sortProjectsByName(projects)
+
+	return append([]Project{defaultProject()}, projects...)
+}
+
+// MoveTask move uma tarefa, junto com suas subtarefas, para outro projeto.
+// Subtarefas acompanham a tarefa principal e não podem ser movidas sozinhas.
+func (tl *TodoList) MoveTask(id int, projectName string) error {
+	if err := tl.validateMove(id, projectName); err != nil {
+		return err
+	}
+
+	project, _ := tl.FindProject(projectName)
+	task, _ := tl.GetTask(id)
+	task.ProjectID = project.ID
+	for _, childID := range tl.descendantIDs(id) {
+		child, _ := tl.GetTask(childID)
+		child.ProjectID = project.ID
+	}
+	return nil
+}
+
+// validateMove verifica se a tarefa pode ir para o projeto, sem movê-la
+func (tl *TodoList) validateMove(id int, projectName string) error {
+	project, err := tl.FindProject(projectName)
+	if err != nil {
+		return err
+	}
+	if project.Archived {
+		return fmt.Errorf("projeto '%s' está arquivado", project.Name)
+	}
+
+	task, err := tl.GetTask(id)
+	if err != nil {
+		return err
+	}
+	if task.IsSubtask() {
+		parent, _ := tl.GetTask(task.ParentID)
+		if parent != nil && parent.ProjectID != project.ID {
+			return fmt.Errorf("subtarefa [%d] acompanha o projeto da tarefa principal [%d]", id, parent.ID)
+		}
+	}
+	return nil
+}
+
+// ProjectView retorna uma cópia da lista contendo apenas as tarefas do
+// projeto informado. Serve para consultas (Stats, SearchTasks, Agenda...)
+// restritas a um projeto; alterações devem ser feitas na lista original.
+func (tl *TodoList) ProjectView(projectID int) *TodoList {
+	return tl.view(func(t *Task) bool {
+		return t.ProjectID == projectID
+	})
+}
+
+// ActiveView retorna uma cópia da lista sem as tarefas de projetos arquivados
+func (tl *TodoList) ActiveView() *TodoList {
+	archived := make(map[int]bool)
+	for _, project := range tl.Projects {
+		if project.Archived {
+			archived[project.ID] = true
+		}
+	}
+
+	return tl.view(func(t *Task) bool {
+		return !archived[t.ProjectID]
+	})
+}
+
+// ProjectStats retorna as estatísticas de cada projeto não arquivado
+func (tl *TodoList) ProjectStats() []ProjectStat {
+	var stats []ProjectStat
+	for _, project := range tl.ListProjects(false) {
+		total, completed, pending := tl.ProjectView(project.ID).Stats()
+		stats = append(stats, ProjectStat{
+			Project:   project,
+			Total:     total,
+			Completed: completed,
+			Pending:   pending,
+		})
+	}
+	return stats
+}
+
+// view cria uma cópia da lista com as tarefas que satisfazem keep
+func (tl *TodoList) view(keep func(t *Task) bool) *TodoList {
+	filtered := &TodoList{
+		Tasks:         make([]Task, 0),
+		NextID:        tl.NextID,
+		Projects:      tl.Projects,
+		NextProjectID: tl.NextProjectID,
+	}
+	for i := range tl.Tasks {
+		if keep(&tl.Tasks[i]) {
+			filtered.Tasks = append(filtered.Tasks, tl.Tasks[i])
+		}
+	}
+	return filtered
+}
+
+// findProject busca um projeto cadastrado pelo nome
+func (tl *TodoList) findProject(name string) (*Project, error) {
+	name = strings.TrimSpace(name)
+	for i := range tl.Projects {
+		if strings.EqualFold(tl.Projects[i].Name, name) {
+			return &tl.Projects[i], nil
+		}
+	}
+	if strings.EqualFold(name, DefaultProjectName) {
+		return nil, fmt.Errorf("o projeto padrão '%s' não pode ser alterado", DefaultProjectName)
+	}
+	return nil, fmt.Errorf("%w: %s", ErrProjectNotFound, name)
+}
+
+// validateProjectName verifica se o nome é válido e ainda não está em uso
+func (tl *TodoList) validateProjectName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return name, fmt.Errorf("nome do projeto não pode ser vazio")
+	}
+	if strings.EqualFold(name, DefaultProjectName) {
+		return name, fmt.Errorf("'%s' é o nome do projeto padrão", DefaultProjectName)
+	}
+	if _, err := tl.findProject(name); err == nil {
+		return name, fmt.Errorf("já existe um projeto chamado '%s'", name)
+	}
+	return name, nil
+}
+
+// sortProjectsByName ordena projetos pelo nome
+func sortProjectsByName(projects []Project) {
+	sort.Slice(projects, func(i, j int) bool {
+		return strings.ToLower(projects[i].Name) < strings.ToLower(projects[j].Name)
+	})
+}
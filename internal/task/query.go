@@ -0,0 +1,432 @@
+package task
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Query é uma consulta já interpretada por ParseQuery. Root nil aceita
+// todas as tarefas.
+type Query struct {
+	Source string
+	Root   Node
+}
+
+// Node é um nó da árvore de uma consulta
+type Node interface {
+	// Match informa se a tarefa satisfaz o nó
+	Match(ctx *MatchContext, t *Task) bool
+}
+
+// MatchContext traz o que a avaliação precisa além da tarefa: a lista,
+// para nomes de projetos e dependências, e o instante atual
+type MatchContext struct {
+	List *TodoList
+	Now  time.Time
+}
+
+// AndNode exige que os dois lados sejam satisfeitos
+type AndNode struct {
+	Left, Right Node
+}
+
+// OrNode exige que ao menos um dos lados seja satisfeito
+type OrNode struct {
+	Left, Right Node
+}
+
+// NotNode inverte o resultado do operando
+type NotNode struct {
+	Operand Node
+}
+
+// TextNode busca o texto no título e na descrição com FuzzyMatch
+type TextNode struct {
+	Text string
+}
+
+// FieldNode compara um campo da tarefa com um valor
+type FieldNode struct {
+	Field string
+	Op    string
+	Value string
+	match func(ctx *MatchContext, t *Task) bool
+}
+
+func (n *AndNode) Match(ctx *MatchContext, t *Task) bool {
+	return n.Left.Match(ctx, t) && n.Right.Match(ctx, t)
+}
+
+func (n *OrNode) Match(ctx *MatchContext, t *Task) bool {
+	return n.Left.Match(ctx, t) || n.Right.Match(ctx, t)
+}
+
+func (n *NotNode) Match(ctx *MatchContext, t *Task) bool {
+	return !n.Operand.Match(ctx, t)
+}
+
+func (n *TextNode) Match(_ *MatchContext, t *Task) bool {
+	_, _, ok := n.Score(t)
+	return ok
+}
+
+// Score pontua a relevância da tarefa para o texto e retorna as posições
+// do título que casaram. Na descrição a pontuação conta pela metade.
+func (n *TextNode) Score(t *Task) (int, []int, bool) {
+	if match, ok := FuzzyMatch(n.Text, t.Title); ok {
+		return match.Score, match.Positions, true
+	}
+	if match, ok := FuzzyMatch(n.Text, t.Description); ok {
+		return match.Score / 2, nil, true
+	}
+	return 0, nil, false
+}
+
+func (n *FieldNode) Match(ctx *MatchContext, t *Task) bool {
+	return n.match(ctx, t)
+}
+
+// Match informa se a tarefa satisfaz a consulta
+func (q *Query) Match(tl *TodoList, t *Task, now time.Time) bool {
+	if q == nil || q.Root == nil {
+		return true
+	}
+	return q.Root.Match(&MatchContext{List: tl, Now: now}, t)
+}
+
+// Filter retorna, na ordem recebida, as tarefas que satisfazem a consulta.
+// tl é usada para resolver projetos e dependências; o seu índice de busca,
+// se ligado (veja EnableSearchIndex), descarta de antemão as tarefas sem as
+// palavras procuradas.
+func (q *Query) Filter(tl *TodoList, tasks []Task, now time.Time) []Task {
+	var index *searchIndex
+	var candidates map[int]struct{}
+	if q != nil && tl != nil && tl.index != nil {
+		index = tl.index
+		candidates = indexCandidates(index, q.Root)
+	}
+
+	var matched []Task
+	for i := range tasks {
+		if candidates != nil && !hasID(candidates, tasks[i].ID) && index.current(&tasks[i]) {
+			continue
+		}
+		if q.Match(tl, &tasks[i], now) {
+			matched = append(matched, tasks[i])
+		}
+	}
+	return matched
+}
+
+// indexCandidates usa o índice para restringir as tarefas que podem
+// satisfazer o nó. Retorna nil se o nó não pode ser restringido, como NOT
+// e comparações de campos.
+func indexCandidates(index *searchIndex, node Node) map[int]struct{} {
+	switch n := node.(type) {
+	case *TextNode:
+		ids, ok := index.lookup(n.Text)
+		if !ok {
+			return nil
+		}
+		return ids
+	case *AndNode:
+		left, right := indexCandidates(index, n.Left), indexCandidates(index, n.Right)
+		if left == nil {
+			return right
+		}
+		if right == nil {
+			return left
+		}
+		both := make(map[int]struct{})
+		for id := range left {
+			if hasID(right, id) {
+				both[id] = struct{}{}
+			}
+		}
+		return both
+	case *OrNode:
+		left, right := indexCandidates(index, n.Left), indexCandidates(index, n.Right)
+		if left == nil || right == nil {
+			return nil
+		}
+		either := make(map[int]struct{}, len(left)+len(right))
+		for id := range left {
+			either[id] = struct{}{}
+		}
+		for id := range right {
+			either[id] = struct{}{}
+		}
+		return either
+	}
+	return nil
+}
+
+// Result é uma tarefa encontrada por Rank, com a pontuação de relevância e
+// as posições (em runas) do título que casaram com os termos da busca
+type Result struct {
+	Task       Task
+	Score      int
+	Highlights []int
+}
+
+// Rank filtra as tarefas como Filter e as ordena pela relevância: a soma
+// das pontuações dos termos de texto que casaram (termos negados não
+// contam). Empates, e consultas sem texto, mantêm a ordem recebida.
+func (q *Query) Rank(tl *TodoList, tasks []Task, now time.Time) []Result {
+	var terms []*TextNode
+	if q != nil {
+		terms = textTerms(q.Root, nil)
+	}
+
+	var results []Result
+	for _, t := range q.Filter(tl, tasks, now) {
+		result := Result{Task: t}
+		highlighted := make(map[int]bool)
+		for _, term := range terms {
+			score, positions, ok := term.Score(&t)
+			if !ok {
+				continue
+			}
+			result.Score += score
+			for _, pos := range positions {
+				if !highlighted[pos] {
+					highlighted[pos] = true
+					result.Highlights = append(result.Highlights, pos)
+				}
+			}
+		}
+		sort.Ints(result.Highlights)
+		results = append(results, result)
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Score > results[j].Score
+	})
+	return results
+}
+
+// textTerms reúne os termos de texto da árvore, exceto os negados
+func textTerms(node Node, terms []*TextNode) []*TextNode {
+	switch n := node.(type) {
+	case *TextNode:
+		return append(terms, n)
+	case *AndNode:
+		return textTerms(n.Right, textTerms(n.Left, terms))
+	case *OrNode:
+		return textTerms(n.Right, textTerms(n.Left, terms))
+	}
+	return terms
+}
+
+// queryFields descreve os campos aceitos e os operadores de cada um
+var queryFields = map[string]string{
+	"status":      ": = !=",
+	"tag":         ": = !=",
+	"priority":    ": = != < <= > >=",
+	"due":         ": = != < <= > >=",
+	"created":     ": = != < <= > >=",
+	"project":     ": = !=",
+	"title":       ": = !=",
+	"description": ": = !=",
+	"id":          ": = != < <= > >=",
+	"parent":      ": = !=",
+}
+
+// queryFieldAliases são nomes alternativos de campos
+var queryFieldAliases = map[string]string{
+	"is":   "status",
+	"tags": "tag",
+	"prio": "priority",
+	"desc": "description",
+}
+
+// isQueryField informa se o nome é um campo da consulta ou um apelido dele
+func isQueryField(name string) bool {
+	name = strings.ToLower(name)
+	_, field := queryFields[name]
+	_, alias := queryFieldAliases[name]
+	return field || alias
+}
+
+// queryFieldNames lista os campos na ordem usada nas mensagens de erro
+var queryFieldNames = []string{
+	"status", "tag", "priority", "due", "created", "project", "title", "description", "id", "parent",
+}
+
+// compileField valida a comparação e prepara a função que a avalia
+func (p *queryParser) compileField(fieldTok, opTok, valueTok token, field string) (Node, error) {
+	if alias, ok := queryFieldAliases[field]; ok {
+		field = alias
+	}
+	ops, ok := queryFields[field]
+	if !ok {
+		return nil, p.errorAt(fieldTok, "campo desconhecido '%s' (use %s)", fieldTok.text, strings.Join(queryFieldNames, ", "))
+	}
+	op := opTok.text
+	if op == "=" {
+		op = ":"
+	}
+	if !containsString(strings.Fields(ops), op) {
+		return nil, p.errorAt(opTok, "o operador '%s' não se aplica ao campo %s (use %s)", opTok.text, field, ops)
+	}
+
+	value := valueTok.text
+	node := &FieldNode{Field: field, Op: op, Value: value}
+	invalid := func(format string, args ...any) (Node, error) {
+		return nil, p.errorAt(valueTok, format, args...)
+	}
+
+	var match func(ctx *MatchContext, t *Task) bool
+	switch field {
+	case "status":
+		status, ok := statusMatchers[strings.ToLower(value)]
+		if !ok {
+			return invalid("status inválido '%s' (use pending, done, overdue, blocked ou ready)", value)
+		}
+		match = status
+
+	case "tag":
+		if strings.EqualFold(value, "none") {
+			match = func(_ *MatchContext, t *Task) bool { return len(t.Tags) == 0 }
+			break
+		}
+		tag, err := NormalizeTag(value)
+		if err != nil {
+			return invalid("%v", err)
+		}
+		match = func(_ *MatchContext, t *Task) bool { return t.HasTag(tag) }
+
+	case "priority":
+		priority, err := ParsePriority(value)
+		if err != nil {
+			return invalid("%v", err)
+		}
+		match = func(_ *MatchContext, t *Task) bool {
+			return compareInts(int(t.Priority), int(priority), op)
+		}
+
+	case "due":
+		if strings.EqualFold(value, "none") {
+			if op != ":" && op != "!=" {
+				return invalid("due:none só aceita ':' ou '!='")
+			}
+			match = func(_ *MatchContext, t *Task) bool { return t.DueDate == nil }
+			break
+		}
+		date, hasTime, err := queryDate(value, p.now)
+		if err != nil {
+			return invalid("%v", err)
+		}
+		match = func(_ *MatchContext, t *Task) bool {
+			return t.DueDate != nil && compareDates(*t.DueDate, date, hasTime, op)
+		}
+
+	case "created":
+		date, hasTime, err := queryDate(value, p.now)
+		if err != nil {
+			return invalid("%v", err)
+		}
+		match = func(_ *MatchContext, t *Task) bool {
+			return compareDates(t.CreatedAt, date, hasTime, op)
+		}
+
+	case "project":
+		match = func(ctx *MatchContext, t *Task) bool {
+			return strings.EqualFold(ctx.List.ProjectName(t.ProjectID), value)
+		}
+
+	case "title":
+		match = func(_ *MatchContext, t *Task) bool {
+			return containsFolded(t.Title, value)
+		}
+
+	case "description":
+		match = func(_ *MatchContext, t *Task) bool {
+			return containsFolded(t.Description, value)
+		}
+
+	case "id", "parent":
+		if field == "parent" && strings.EqualFold(value, "none") {
+			match = func(_ *MatchContext, t *Task) bool { return t.ParentID == 0 }
+			break
+		}
+		id, err := strconv.Atoi(strings.Trim(value, "[]"))
+		if err != nil {
+			return invalid("ID inválido '%s'", value)
+		}
+		match = func(_ *MatchContext, t *Task) bool {
+			if field == "parent" {
+				return t.ParentID == id
+			}
+			return compareInts(t.ID, id, op)
+		}
+	}
+
+	// ":" e "!=" são tratados aqui; as comparações de ordem, em cada campo
+	if op == "!=" {
+		positive := match
+		match = func(ctx *MatchContext, t *Task) bool { return !positive(ctx, t) }
+	}
+	node.match = match
+	return node, nil
+}
+
+// statusMatchers avalia cada valor aceito em status:
+var statusMatchers = map[string]func(ctx *MatchContext, t *Task) bool{
+	"pending":   func(_ *MatchContext, t *Task) bool { return !t.Completed },
+	"open":      func(_ *MatchContext, t *Task) bool { return !t.Completed },
+	"done":      func(_ *MatchContext, t *Task) bool { return t.Completed },
+	"completed": func(_ *MatchContext, t *Task) bool { return t.Completed },
+	"overdue":   func(ctx *MatchContext, t *Task) bool { return t.IsOverdue(ctx.Now) },
+	"blocked":   func(ctx *MatchContext, t *Task) bool { return ctx.List.IsBlocked(t.ID) },
+	"ready": func(ctx *MatchContext, t *Task) bool {
+		return !t.Completed && !ctx.List.IsBlocked(t.ID)
+	},
+}
+
+// queryDate interpreta uma data da consulta: além do aceito por ParseDue,
+// "semana" (o domingo desta semana) e dias a partir de hoje, como "+7d" ou
+// "-3d", que mantêm consultas salvas sempre atuais
+func queryDate(value string, now time.Time) (time.Time, bool, error) {
+	lower := strings.ToLower(value)
+	switch {
+	case lower == "semana" || lower == "week":
+		return endOfWeek(now).AddDate(0, 0, -1), false, nil
+	case strings.HasPrefix(lower, "+") || strings.HasPrefix(lower, "-"):
+		days, err := strconv.Atoi(strings.TrimSuffix(lower, "d"))
+		if err != nil {
+			return time.Time{}, false, fmt.Errorf("data relativa inválida: %s (use +Nd ou -Nd)", value)
+		}
+		return startOfDay(now).AddDate(0, 0, days), false, nil
+	}
+	return ParseDue(value, now)
+}
+
+// compareInts aplica um operador de comparação (":" é igualdade)
+func compareInts(a, b int, op string) bool {
+	switch op {
+	case "<":
+		return a < b
+	case "<=":
+		return a <= b
+	case ">":
+		return a > b
+	case ">=":
+		return a >= b
+	default: // ":" e "!=", que é invertido por quem chama
+		return a == b
+	}
+}
+
+// compareDates compara uma data da tarefa com o valor da consulta. Sem
+// horário no valor, a comparação é por dia: due<=2026-11-01 inclui o dia 1º.
+func compareDates(t, value time.Time, hasTime bool, op string) bool {
+	if hasTime {
+		return compareInts(t.Compare(value), 0, op)
+	}
+	day := startOfDay(t.In(value.Location()))
+	return compareInts(day.Compare(startOfDay(value)), 0, op)
+}
@@ -0,0 +1,316 @@
+package task
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// QueryError é um erro de sintaxe em uma consulta, com a posição (em
+// caracteres, a partir de zero) onde ele foi encontrado
+type QueryError struct {
+	Query string
+	Pos   int
+	Msg   string
+}
+
+func (e *QueryError) Error() string {
+	return fmt.Sprintf("consulta inválida (posição %d): %s", e.Pos+1, e.Msg)
+}
+
+// Pointer retorna a consulta com um marcador "^" sob a posição do erro
+func (e *QueryError) Pointer() string {
+	return e.Query + "\n" + strings.Repeat(" ", e.Pos) + "^"
+}
+
+// tokenKind identifica o tipo de um token da consulta
+type tokenKind int
+
+const (
+	tokEOF    tokenKind = iota
+	tokWord             // palavra solta, nome de campo ou valor
+	tokString           // texto entre aspas
+	tokOp               // operador de comparação: : = != < <= > >=
+	tokLParen           // (
+	tokRParen           // )
+	tokMinus            // - no início de um termo (negação)
+)
+
+// token é um elemento léxico da consulta
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+// queryOperators lista os operadores, os de dois caracteres primeiro
+var queryOperators = []string{"!=", "<=", ">=", ":", "=", "<", ">"}
+
+// lexQuery divide a consulta em tokens. Operadores só são reconhecidos
+// colados a um nome de campo (due<2026-11-01); em outras palavras, como
+// "10:30" ou uma URL, fazem parte do texto. O valor que segue um operador
+// vai até o próximo espaço ou parêntese e pode conter ":".
+func lexQuery(query string) ([]token, error) {
+	runes := []rune(query)
+	var tokens []token
+	afterOp := false
+
+	fail := func(pos int, format string, args ...any) ([]token, error) {
+		return nil, &QueryError{Query: query, Pos: pos, Msg: fmt.Sprintf(format, args...)}
+	}
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			if afterOp {
+				return fail(i, "valor ausente após o operador")
+			}
+			i++
+		case r == '(' || r == ')':
+			if afterOp {
+				return fail(i, "valor ausente após o operador")
+			}
+			kind := tokLParen
+			if r == ')' {
+				kind = tokRParen
+			}
+			tokens = append(tokens, token{kind: kind, text: string(r), pos: i})
+			i++
+		case r == '"':
+			end := i + 1
+			for end < len(runes) && runes[end] != '"' {
+				end++
+			}
+			if end == len(runes) {
+				return fail(i, "aspas sem fechamento")
+			}
+			tokens = append(tokens, token{kind: tokString, text: string(runes[i+1 : end]), pos: i})
+			afterOp = false
+			i = end + 1
+		case r == '-' && !afterOp && (len(tokens) == 0 || !adjacent(tokens, i)):
+			tokens = append(tokens, token{kind: tokMinus, text: "-", pos: i})
+			i++
+		case afterOp:
+			start := i
+			for i < len(runes) && !unicode.IsSpace(runes[i]) && runes[i] != '(' && runes[i] != ')' {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokWord, text: string(runes[start:i]), pos: start})
+			afterOp = false
+		default:
+			if op := operatorAt(runes, i); op != "" {
+				if len(tokens) == 0 || tokens[len(tokens)-1].kind != tokWord || !adjacent(tokens, i) {
+					return fail(i, "operador '%s' sem nome de campo (ex.: status:pending)", op)
+				}
+				tokens = append(tokens, token{kind: tokOp, text: op, pos: i})
+				i += len([]rune(op))
+				afterOp = true
+				if i == len(runes) {
+					return fail(i, "valor ausente após o operador")
+				}
+				continue
+			}
+
+			start := i
+			for i < len(runes) && !unicode.IsSpace(runes[i]) && !strings.ContainsRune(`()"`, runes[i]) &&
+				operatorAt(runes, i) == "" {
+				i++
+			}
+			if i < len(runes) && operatorAt(runes, i) != "" && !isQueryField(string(runes[start:i])) {
+				// Sem um campo antes do operador ("10:30", URLs), a palavra
+				// inteira é texto a buscar
+				for i < len(runes) && !unicode.IsSpace(runes[i]) && !strings.ContainsRune(`()"`, runes[i]) {
+					i++
+				}
+			}
+			tokens = append(tokens, token{kind: tokWord, text: string(runes[start:i]), pos: start})
+		}
+	}
+
+	return append(tokens, token{kind: tokEOF, pos: len(runes)}), nil
+}
+
+// operatorAt retorna o operador que começa na posição i, se houver
+func operatorAt(runes []rune, i int) string {
+	for _, op := range queryOperators {
+		n := len([]rune(op))
+		if i+n <= len(runes) && string(runes[i:i+n]) == op {
+			return op
+		}
+	}
+	return ""
+}
+
+// adjacent informa se o último token termina exatamente na posição pos
+func adjacent(tokens []token, pos int) bool {
+	last := tokens[len(tokens)-1]
+	length := len([]rune(last.text))
+	if last.kind == tokString {
+		length += 2
+	}
+	return last.pos+length == pos
+}
+
+// queryParser monta a árvore da consulta a partir dos tokens:
+//
+//	or      = and { "OR" and }
+//	and     = not { ["AND"] not }
+//	not     = ("NOT" | "-") not | primary
+//	primary = "(" or ")" | campo operador valor | palavra | "frase"
+type queryParser struct {
+	query  string
+	tokens []token
+	pos    int
+	now    time.Time
+}
+
+// ParseQuery interpreta uma consulta como
+//
+//	status:pending tag:infra due<2026-11-01 "deploy" -wip
+//
+// Termos lado a lado precisam ser todos satisfeitos (AND); OR, NOT e
+// parênteses combinam termos, e "-" nega o termo seguinte. Palavras e
+// frases entre aspas são buscadas no título e na descrição. Datas
+// relativas ("hoje") usam now. Uma consulta vazia aceita todas as tarefas.
+func ParseQuery(query string, now time.Time) (*Query, error) {
+	tokens, err := lexQuery(query)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &queryParser{query: query, tokens: tokens, now: now}
+	if p.peek().kind == tokEOF {
+		return &Query{Source: query}, nil
+	}
+
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != tokEOF {
+		if tok.kind == tokRParen {
+			return nil, p.errorAt(tok, "')' sem '(' correspondente")
+		}
+		return nil, p.errorAt(tok, "termo inesperado '%s'", tok.text)
+	}
+	return &Query{Source: query, Root: root}, nil
+}
+
+// peek retorna o token atual sem consumi-lo
+func (p *queryParser) peek() token {
+	return p.tokens[p.pos]
+}
+
+// next consome e retorna o token atual
+func (p *queryParser) next() token {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokEOF {
+		p.pos++
+	}
+	return tok
+}
+
+// isKeyword informa se o token é a palavra-chave informada (em maiúsculas)
+func isKeyword(tok token, keyword string) bool {
+	return tok.kind == tokWord && tok.text == keyword
+}
+
+// errorAt cria um erro de sintaxe na posição do token
+func (p *queryParser) errorAt(tok token, format string, args ...any) error {
+	return &QueryError{Query: p.query, Pos: tok.pos, Msg: fmt.Sprintf(format, args...)}
+}
+
+func (p *queryParser) parseOr() (Node, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for isKeyword(p.peek(), "OR") {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &OrNode{Left: left, Right: right}
+	}
+	return left, nil
+}
+
+func (p *queryParser) parseAnd() (Node, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		tok := p.peek()
+		if tok.kind == tokEOF || tok.kind == tokRParen || isKeyword(tok, "OR") {
+			return left, nil
+		}
+		if isKeyword(tok, "AND") {
+			p.next()
+		}
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = &AndNode{Left: left, Right: right}
+	}
+}
+
+func (p *queryParser) parseNot() (Node, error) {
+	if tok := p.peek(); tok.kind == tokMinus || isKeyword(tok, "NOT") {
+		p.next()
+		operand, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return &NotNode{Operand: operand}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *queryParser) parsePrimary() (Node, error) {
+	tok := p.next()
+	switch tok.kind {
+	case tokLParen:
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if closing := p.next(); closing.kind != tokRParen {
+			return nil, p.errorAt(closing, "esperado ')' para fechar o '(' da posição %d", tok.pos+1)
+		}
+		return inner, nil
+	case tokString:
+		return &TextNode{Text: tok.text}, nil
+	case tokWord:
+		if isKeyword(tok, "AND") || isKeyword(tok, "OR") || isKeyword(tok, "NOT") {
+			return nil, p.errorAt(tok, "esperado um termo antes de '%s'", tok.text)
+		}
+		if p.peek().kind == tokOp {
+			return p.parseField(tok)
+		}
+		if strings.HasPrefix(tok.text, "#") {
+			return p.compileField(tok, token{kind: tokOp, text: ":", pos: tok.pos}, token{text: tok.text[1:], pos: tok.pos}, "tag")
+		}
+		return &TextNode{Text: tok.text}, nil
+	case tokEOF:
+		return nil, p.errorAt(tok, "consulta incompleta: esperado um termo")
+	case tokRParen:
+		return nil, p.errorAt(tok, "')' inesperado")
+	default:
+		return nil, p.errorAt(tok, "termo inesperado '%s'", tok.text)
+	}
+}
+
+// parseField lê uma comparação campo-operador-valor
+func (p *queryParser) parseField(field token) (Node, error) {
+	op := p.next()
+	value := p.next()
+	if value.kind != tokWord && value.kind != tokString {
+		return nil, p.errorAt(value, "valor ausente após '%s%s'", field.text, op.text)
+	}
+	return p.compileField(field, op, value, strings.ToLower(field.text))
+}
@@ -0,0 +1,146 @@
+package task
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// queryNow é o "agora" das consultas dos testes
+var queryNow = time.Date(2026, time.October, 18, 9, 0, 0, 0, time.Local)
+
+// tree escreve a árvore da consulta de forma compacta: "*" aceita tudo,
+// textos ficam entre aspas e campos como campo+operador+valor
+func tree(node Node) string {
+	switch n := node.(type) {
+	case nil:
+		return "*"
+	case *AndNode:
+		return fmt.Sprintf("(%s AND %s)", tree(n.Left), tree(n.Right))
+	case *OrNode:
+		return fmt.Sprintf("(%s OR %s)", tree(n.Left), tree(n.Right))
+	case *NotNode:
+		return "-" + tree(n.Operand)
+	case *TextNode:
+		return fmt.Sprintf("%q", n.Text)
+	case *FieldNode:
+		return n.Field + n.Op + n.Value
+	}
+	return fmt.Sprintf("%T", node)
+}
+
+// ids lista os IDs das tarefas, na ordem recebida
+func ids(tasks []Task) []int {
+	list := make([]int, len(tasks))
+	for i, t := range tasks {
+		list[i] = t.ID
+	}
+	return list
+}
+
+func TestParseQueryTree(t *testing.T) {
+	for _, tt := range []struct{ query, tree string }{
+		{"", "*"},
+		{"deploy", `"deploy"`},
+		{`"deploy da api"`, `"deploy da api"`},
+		{"deploy api", `("deploy" AND "api")`},
+		{"deploy AND api", `("deploy" AND "api")`},
+		{"a b OR c", `(("a" AND "b") OR "c")`},
+		{"a (b OR c)", `("a" AND ("b" OR "c"))`},
+		{"-wip", `-"wip"`},
+		{"NOT NOT wip", `--"wip"`},
+		{"status:pending", "status:pending"},
+		{"IS:done", "status:done"},
+		{"tag=infra", "tag:infra"},
+		{"#infra -#wip", "(tag:infra AND -tag:wip)"},
+		{"prio>=high", "priority>=high"},
+		{"due<2026-11-01", "due<2026-11-01"},
+		{`title:"deploy da api"`, "title:deploy da api"},
+		{"project:casa/obra", "project:casa/obra"},
+
+		// Operadores sem um campo conhecido antes fazem parte do texto
+		{"10:30", `"10:30"`},
+		{"reunião 10:30", `("reunião" AND "10:30")`},
+		{"https://example.com/a?b=c", `"https://example.com/a?b=c"`},
+		{"a<b x>=1", `("a<b" AND "x>=1")`},
+		{"stauts:pending", `"stauts:pending"`},
+		{"-10:30", `-"10:30"`},
+		{"(10:30 OR 11:00)", `("10:30" OR "11:00")`},
+	} {
+		query, err := ParseQuery(tt.query, queryNow)
+		if err != nil {
+			t.Errorf("ParseQuery(%q): %v", tt.query, err)
+			continue
+		}
+		if got := tree(query.Root); got != tt.tree {
+			t.Errorf("ParseQuery(%q) = %s, esperado %s", tt.query, got, tt.tree)
+		}
+	}
+}
+
+// Os erros apontam com "^" onde a consulta deixou de fazer sentido
+func TestParseQueryErrorPointer(t *testing.T) {
+	for _, pointer := range []string{
+		"\"deploy\n^",
+		"status:\n       ^",
+		"status: pending\n       ^",
+		"status :pending\n       ^",
+		":pending\n^",
+		"(deploy\n       ^",
+		"deploy)\n      ^",
+		"deploy OR\n         ^",
+		"AND deploy\n^",
+		"status:talvez\n       ^",
+		"title<abc\n     ^",
+	} {
+		query, _, _ := strings.Cut(pointer, "\n")
+		_, err := ParseQuery(query, queryNow)
+		var queryErr *QueryError
+		if !errors.As(err, &queryErr) {
+			t.Errorf("ParseQuery(%q) = %v, esperado um QueryError", query, err)
+			continue
+		}
+		if got := queryErr.Pointer(); got != pointer {
+			t.Errorf("ParseQuery(%q): %s\n%s\nesperado\n%s", query, queryErr.Msg, got, pointer)
+		}
+	}
+}
+
+func TestQueryFilter(t *testing.T) {
+	tl := NewTodoList()
+	tl.AddTask("Deploy da API #infra", "subir às 10:30")
+	contract := tl.AddTask("Revisar contrato", "https://example.com/contrato")
+	due := time.Date(2026, time.October, 20, 0, 0, 0, 0, time.Local)
+	contract.DueDate = &due
+	contract.Priority = PriorityHigh
+	tl.AddTask("Backup do banco", "")
+	if err := tl.ToggleTask(3); err != nil {
+		t.Fatal(err)
+	}
+
+	for query, want := range map[string][]int{
+		"deploy":                       {1},
+		"10:30":                        {1},
+		"https://example.com/contrato": {2},
+		"status:pending":               {1, 2},
+		"-status:done":                 {1, 2},
+		"tag:infra OR backup":          {1, 3},
+		"tag:none":                     {2, 3},
+		"due<2026-10-21":               {2},
+		"due:none":                     {1, 3},
+		"priority>=medium":             {2},
+		"id>=2 -backup":                {2},
+	} {
+		parsed, err := ParseQuery(query, queryNow)
+		if err != nil {
+			t.Errorf("ParseQuery(%q): %v", query, err)
+			continue
+		}
+		if got := ids(parsed.Filter(tl, tl.Tasks, queryNow)); !reflect.DeepEqual(got, want) {
+			t.Errorf("%q encontrou %v, esperado %v", query, got, want)
+		}
+	}
+}
@@ -0,0 +1,212 @@
+package task
+
+import (
+	"fmt"
+	"strings"
+)
+
+// rankDigits são os dígitos das posições, em ordem crescente
+const rankDigits = "0123456789abcdefghijklmnopqrstuvwxyz"
+
+// rankWidth é o tamanho da parte inteira das posições atribuídas no fim ou
+// no início da lista ("00001i", "00002i", ...). Posições entre duas tarefas
+// ganham dígitos a mais, sem renumerar as demais.
+const rankWidth = 5
+
+// rankSuffix encerra as posições inteiras. Nenhuma posição termina com o
+// menor dígito, para que sempre caiba outra antes dela.
+const rankSuffix = "i"
+
+// MoveTaskBefore coloca a tarefa imediatamente antes de outra na ordem
+// manual (veja SortRank). Só a posição da tarefa movida muda: se a outra
+// não tem posição (arquivo editado à mão), ela está no fim da ordem, depois
+// de todas as que têm, e a tarefa movida vai para o fim destas.
+func (tl *TodoList) MoveTaskBefore(id, beforeID int) error {
+	if id == beforeID {
+		return fmt.Errorf("tarefa não pode ser movida para antes dela mesma")
+	}
+	task, err := tl.GetTask(id)
+	if err != nil {
+		return err
+	}
+	before, err := tl.GetTask(beforeID)
+	if err != nil {
+		return err
+	}
+
+	if before.Rank == "" {
+		_, last := tl.rankBounds(id)
+		task.Rank = rankAfter(last)
+		return nil
+	}
+
+	// A tarefa fica entre a anterior a before e before
+	previous := ""
+	for _, t := range tl.rankedTasks() {
+		if t.ID != id && t.Rank < before.Rank && t.Rank > previous {
+			previous = t.Rank
+		}
+	}
+	task.Rank = rankBetween(previous, before.Rank)
+	return nil
+}
+
+// MoveTaskToTop coloca a tarefa antes de todas as outras na ordem manual
+func (tl *TodoList) MoveTaskToTop(id int) error {
+	task, err := tl.GetTask(id)
+	if err != nil {
+		return err
+	}
+	first, _ := tl.rankBounds(id)
+	task.Rank = rankBefore(first)
+	return nil
+}
+
+// MoveTaskToBottom coloca a tarefa depois de todas as outras na ordem manual
+func (tl *TodoList) MoveTaskToBottom(id int) error {
+	task, err := tl.GetTask(id)
+	if err != nil {
+		return err
+	}
+	_, last := tl.rankBounds(id)
+	task.Rank = rankAfter(last)
+	return nil
+}
+
+// nextRank retorna a posição de uma tarefa nova, no fim da lista
+func (tl *TodoList) nextRank() string {
+	_, last := tl.rankBounds(0)
+	return rankAfter(last)
+}
+
+// rankAfterTask retorna uma posição logo depois da tarefa, antes da
+// seguinte na ordem manual
+func (tl *TodoList) rankAfterTask(t *Task) string {
+	if t.Rank == "" {
+		return tl.nextRank()
+	}
+	next := ""
+	for _, other := range tl.rankedTasks() {
+		if other.Rank > t.Rank && (next == "" || other.Rank < next) {
+			next = other.Rank
+		}
+	}
+	if next == "" {
+		return rankAfter(t.Rank)
+	}
+	return rankBetween(t.Rank, next)
+}
+
+// rankBounds retorna a menor e a maior posição das tarefas, da lista e da
+// lixeira (que voltam para a mesma posição ao serem restauradas), exceto a
+// do ID informado. Tarefas sem posição são ignoradas.
+func (tl *TodoList) rankBounds(except int) (first, last string) {
+	for _, t := range tl.rankedTasks() {
+		if t.ID == except {
+			continue
+		}
+		if first == "" || t.Rank < first {
+			first = t.Rank
+		}
+		if t.Rank > last {
+			last = t.Rank
+		}
+	}
+	return first, last
+}
+
+// rankedTasks retorna as tarefas da lista e da lixeira que têm posição
+func (tl *TodoList) rankedTasks() []*Task {
+	var ranked []*Task
+	for _, list := range [][]Task{tl.Tasks, tl.Trash} {
+		for i := range list {
+			if list[i].Rank != "" {
+				ranked = append(ranked, &list[i])
+			}
+		}
+	}
+	return ranked
+}
+
+// rankAfter retorna uma posição inteira depois de last; vazio é o início
+func rankAfter(last string) string {
+	n := rankInteger(last)
+	if n+1 >= rankLimit() {
+		return rankBetween(last, "")
+	}
+	return formatRank(n + 1)
+}
+
+// rankBefore retorna uma posição antes de first; vazio é o fim
+func rankBefore(first string) string {
+	if first == "" {
+		return formatRank(1)
+	}
+	if n := rankInteger(first); n >= 1 {
+		return formatRank(n - 1)
+	}
+	return rankBetween("", first)
+}
+
+// rankBetween retorna a posição no meio de a e b, que devem estar em
+// ordem. Vazio representa o início (em a) ou o fim (em b) da lista.
+func rankBetween(a, b string) string {
+	var rank strings.Builder
+	for i := 0; ; i++ {
+		low := rankDigit(a, i, 0)
+		high := rankDigit(b, i, len(rankDigits))
+
+		if low == high {
+			rank.WriteByte(rankDigits[low])
+			continue
+		}
+		if mid := (low + high) / 2; mid > low {
+			rank.WriteByte(rankDigits[mid])
+			return rank.String()
+		}
+
+		// Dígitos vizinhos: mantém o de a e procura espaço depois dele,
+		// onde o limite passa a ser o fim
+		rank.WriteByte(rankDigits[low])
+		b = ""
+	}
+}
+
+// rankInteger lê a parte inteira de uma posição (os primeiros rankWidth
+// dígitos, completados com zeros)
+func rankInteger(rank string) int {
+	n := 0
+	for i := 0; i < rankWidth; i++ {
+		n = n*len(rankDigits) + rankDigit(rank, i, 0)
+	}
+	return n
+}
+
+// rankDigit retorna o valor do i-ésimo dígito da posição, ou missing se
+// ela for mais curta. Caracteres inválidos (de arquivos editados à mão)
+// valem zero.
+func rankDigit(rank string, i, missing int) int {
+	if i >= len(rank) {
+		return missing
+	}
+	return max(0, strings.IndexByte(rankDigits, rank[i]))
+}
+
+// formatRank monta a posição inteira n
+func formatRank(n int) string {
+	digits := make([]byte, rankWidth)
+	for i := rankWidth - 1; i >= 0; i-- {
+		digits[i] = rankDigits[n%len(rankDigits)]
+		n /= len(rankDigits)
+	}
+	return string(digits) + rankSuffix
+}
+
+// rankLimit é a primeira parte inteira que não cabe em rankWidth dígitos
+func rankLimit() int {
+	limit := 1
+	for i := 0; i < rankWidth; i++ {
+		limit *= len(rankDigits)
+	}
+	return limit
+}
@@ -0,0 +1,145 @@
+package task
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// manualOrder retorna os IDs das tarefas na ordem manual
+func manualOrder(tl *TodoList) []int {
+	sorted := append([]Task(nil), tl.Tasks...)
+	SortSpec{{Key: SortRank}}.Sort(sorted)
+	return ids(sorted)
+}
+
+func TestRankBetween(t *testing.T) {
+	for _, tt := range []struct{ a, b, want string }{
+		{"", "", "i"},
+		{"", "00001i", "00000i"},
+		{"00002i", "", "i"},
+		{"00001i", "00003i", "00002"},
+		{"00001i", "00002i", "00001r"},
+		{"00001i", "00001j", "00001ii"}, // dígitos vizinhos ganham mais um
+		{"00001", "00001i", "000019"},   // a é prefixo de b
+		{"", "000001", "000000i"},
+		{"zzzzzi", "", "zzzzzr"},
+	} {
+		got := rankBetween(tt.a, tt.b)
+		if got != tt.want {
+			t.Errorf("rankBetween(%q, %q) = %q, esperado %q", tt.a, tt.b, got, tt.want)
+		}
+		if got <= tt.a || (tt.b != "" && got >= tt.b) {
+			t.Errorf("rankBetween(%q, %q) = %q está fora do intervalo", tt.a, tt.b, got)
+		}
+	}
+}
+
+// Inserir sempre no mesmo ponto alonga as posições, mas nunca esgota o
+// espaço entre duas delas
+func TestRankBetweenNeverRunsOut(t *testing.T) {
+	a, b := formatRank(1), formatRank(2)
+	for i := 0; i < 200; i++ {
+		if i%2 == 0 {
+			a = rankBetween(a, b)
+		} else {
+			b = rankBetween(a, b)
+		}
+		if a >= b || strings.HasSuffix(a, "0") || strings.HasSuffix(b, "0") {
+			t.Fatalf("inserção %d: posições %q e %q", i, a, b)
+		}
+	}
+}
+
+func TestRankAfterAndBefore(t *testing.T) {
+	for got, want := range map[string]string{
+		rankAfter(""):         "00001i",
+		rankAfter("00009i"):   "0000ai",
+		rankAfter("00001ii"):  "00002i",
+		rankAfter("zzzzzi"):   "zzzzzr",
+		rankBefore("00003i"):  "00002i",
+		rankBefore("00000i"):  "000009",
+		rankBefore("zzzzzzi"): "zzzzyi",
+	} {
+		if got != want {
+			t.Errorf("posição %q, esperado %q", got, want)
+		}
+	}
+	if got := rankBefore(""); got != "00001i" {
+		t.Errorf("rankBefore(\"\") = %q, esperado 00001i", got)
+	}
+}
+
+func TestMoveTask(t *testing.T) {
+	tl := NewTodoList()
+	for _, title := range []string{"um", "dois", "três", "quatro"} {
+		tl.AddTask(title, "")
+	}
+	untouched := tl.Tasks[1].Rank
+
+	steps := []struct {
+		move func() error
+		want []int
+	}{
+		{func() error { return tl.MoveTaskToTop(3) }, []int{3, 1, 2, 4}},
+		{func() error { return tl.MoveTaskBefore(4, 1) }, []int{3, 4, 1, 2}},
+		{func() error { return tl.MoveTaskToBottom(3) }, []int{4, 1, 2, 3}},
+		{func() error { return tl.MoveTaskBefore(1, 2) }, []int{4, 1, 2, 3}},
+		{func() error { return tl.MoveTaskBefore(3, 4) }, []int{3, 4, 1, 2}},
+	}
+	for i, step := range steps {
+		if err := step.move(); err != nil {
+			t.Fatalf("passo %d: %v", i+1, err)
+		}
+		if got := manualOrder(tl); !reflect.DeepEqual(got, step.want) {
+			t.Errorf("passo %d: ordem %v, esperado %v", i+1, got, step.want)
+		}
+	}
+
+	// Só as tarefas movidas mudam de posição
+	if tl.Tasks[1].Rank != untouched {
+		t.Errorf("tarefa 2 nunca foi movida, mas foi de %q para %q", untouched, tl.Tasks[1].Rank)
+	}
+
+	if err := tl.MoveTaskBefore(1, 1); err == nil {
+		t.Error("mover a tarefa para antes dela mesma deveria falhar")
+	}
+	if err := tl.MoveTaskToTop(99); err == nil {
+		t.Error("mover uma tarefa inexistente deveria falhar")
+	}
+}
+
+// Tarefas removidas guardam a posição para voltar ao mesmo lugar, e as
+// novas vão para depois delas
+func TestNewTaskRankSkipsTrash(t *testing.T) {
+	tl := NewTodoList()
+	tl.AddTask("um", "")
+	tl.AddTask("dois", "")
+	if err := tl.RemoveTask(2); err != nil {
+		t.Fatal(err)
+	}
+	three := tl.AddTask("três", "")
+	if three.Rank <= tl.Trash[0].Rank {
+		t.Errorf("tarefa nova em %q, antes da removida em %q", three.Rank, tl.Trash[0].Rank)
+	}
+}
+
+// Uma tarefa sem posição fica no fim da ordem manual e não ganha uma ao
+// servir de referência para outra
+func TestMoveTaskBeforeUnranked(t *testing.T) {
+	tl := NewTodoList()
+	for _, title := range []string{"um", "dois", "três"} {
+		tl.AddTask(title, "")
+	}
+	tl.Tasks[1].Rank = ""
+
+	if err := tl.MoveTaskBefore(1, 2); err != nil {
+		t.Fatal(err)
+	}
+	if tl.Tasks[1].Rank != "" {
+		t.Errorf("tarefa de referência ganhou a posição %q", tl.Tasks[1].Rank)
+	}
+	if got, want := manualOrder(tl), []int{3, 1, 2}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ordem = %v, esperado %v", got, want)
+	}
+}
@@ -0,0 +1,364 @@
+package task
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RecurrenceUnit é a unidade do intervalo de repetição
+type RecurrenceUnit string
+
+// Unidades de repetição suportadas
+const (
+	UnitDay   RecurrenceUnit = "day"
+	UnitWeek  RecurrenceUnit = "week"
+	UnitMonth RecurrenceUnit = "month"
+)
+
+// Recurrence descreve como uma tarefa se repete. Ao concluir uma tarefa
+// recorrente, a próxima ocorrência é criada com o prazo recalculado.
+type Recurrence struct {
+	Every    int            `json:"every"`
+	Unit     RecurrenceUnit `json:"unit"`
+	Weekdays []time.Weekday `json:"weekdays,omitempty"`  // dias da semana (apenas UnitWeek)
+	MonthDay int            `json:"month_day,omitempty"` // dia do mês (apenas UnitMonth)
+
+	// AfterCompletion conta o intervalo a partir da conclusão, e não do prazo
+	AfterCompletion bool `json:"after_completion,omitempty"`
+}
+
+// weekdayCodes associa os nomes aceitos (inglês e português) aos dias da semana
+var weekdayCodes = map[string]time.Weekday{
+	"sun": time.Sunday, "dom": time.Sunday,
+	"mon": time.Monday, "seg": time.Monday,
+	"tue": time.Tuesday, "ter": time.Tuesday,
+	"wed": time.Wednesday, "qua": time.Wednesday,
+	"thu": time.Thursday, "qui": time.Thursday,
+	"fri": time.Friday, "sex": time.Friday,
+	"sat": time.Saturday, "sab": time.Saturday, "sáb": time.Saturday,
+}
+
+// weekdayShort guarda a abreviação canônica de cada dia da semana
+var weekdayShort = [...]string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}
+
+// weekdayLabels guarda a abreviação em português de cada dia da semana
+var weekdayLabels = [...]string{"dom", "seg", "ter", "qua", "qui", "sex", "sáb"}
+
+// ParseRecurrence interpreta uma regra de repetição. Formatos aceitos:
+//
+//	daily | weekly[:mon,fri] | monthly[:15]
+//	every:<N><d|w|m>[:dias da semana | :dia do mês]
+//	after:<N><d|w|m>   (conta a partir da conclusão)
+//
+// Os dias da semana aceitam abreviações em inglês ou português (seg, ter...).
+func ParseRecurrence(rule string) (*Recurrence, error) {
+	rule = strings.ToLower(strings.TrimSpace(rule))
+	if rule == "" {
+		return nil, fmt.Errorf("regra de recorrência vazia")
+	}
+
+	parts := strings.Split(rule, ":")
+	invalid := fmt.Errorf("recorrência inválida: %s (ex.: daily, weekly:mon,fri, monthly:15, every:3d, after:1w)", rule)
+
+	r := &Recurrence{Every: 1}
+	var detail string
+	switch parts[0] {
+	case "daily", "diaria", "diária":
+		r.Unit = UnitDay
+		if len(parts) > 1 {
+			return nil, invalid
+		}
+	case "weekly", "semanal":
+		r.Unit = UnitWeek
+		detail = strings.Join(parts[1:], ":")
+	case "monthly", "mensal":
+		r.Unit = UnitMonth
+		detail = strings.Join(parts[1:], ":")
+	case "every", "after":
+		if len(parts) < 2 {
+			return nil, invalid
+		}
+		every, unit, err := parseInterval(parts[1])
+		if err != nil {
+			return nil, invalid
+		}
+		r.Every, r.Unit = every, unit
+		r.AfterCompletion = parts[0] == "after"
+		detail = strings.Join(parts[2:], ":")
+		if r.AfterCompletion && detail != "" {
+			return nil, invalid
+		}
+	default:
+		return nil, invalid
+	}
+
+	if detail != "" {
+		if err := r.parseDetail(detail); err != nil {
+			return nil, fmt.Errorf("%w: %v", invalid, err)
+		}
+	}
+	return r, nil
+}
+
+// parseInterval interpreta intervalos como "3d", "2w" ou "1m"
+func parseInterval(s string) (int, RecurrenceUnit, error) {
+	if len(s) < 2 {
+		return 0, "", fmt.Errorf("intervalo inválido: %s", s)
+	}
+
+	units := map[byte]RecurrenceUnit{'d': UnitDay, 'w': UnitWeek, 's': UnitWeek, 'm': UnitMonth}
+	unit, ok := units[s[len(s)-1]]
+	if !ok {
+		return 0, "", fmt.Errorf("unidade inválida: %s", s)
+	}
+
+	every, err := strconv.Atoi(s[:len(s)-1])
+	if err != nil || every < 1 {
+		return 0, "", fmt.Errorf("intervalo inválido: %s", s)
+	}
+	return every, unit, nil
+}
+
+// parseDetail interpreta os dias da semana (UnitWeek) ou o dia do mês (UnitMonth)
+func (r *Recurrence) parseDetail(detail string) error {
+	switch r.Unit {
+	case UnitWeek:
+		for _, code := range strings.Split(detail, ",") {
+			weekday, ok := weekdayCodes[strings.TrimSpace(code)]
+			if !ok {
+				return fmt.Errorf("dia da semana inválido: %s", code)
+			}
+			if !containsWeekday(r.Weekdays, weekday) {
+				r.Weekdays = append(r.Weekdays, weekday)
+			}
+		}
+		sort.Slice(r.Weekdays, func(i, j int) bool { return r.Weekdays[i] < r.Weekdays[j] })
+	case UnitMonth:
+		day, err := strconv.Atoi(detail)
+		if err != nil || day < 1 || day > 31 {
+			return fmt.Errorf("dia do mês inválido: %s", detail)
+		}
+		r.MonthDay = day
+	default:
+		return fmt.Errorf("detalhe não suportado para repetição diária")
+	}
+	return nil
+}
+
+// String retorna a regra no formato aceito por ParseRecurrence
+func (r *Recurrence) String() string {
+	unitCodes := map[RecurrenceUnit]string{UnitDay: "d", UnitWeek: "w", UnitMonth: "m"}
+
+	var rule string
+	switch {
+	case r.AfterCompletion:
+		return fmt.Sprintf("after:%d%s", r.Every, unitCodes[r.Unit])
+	case r.Every == 1 && r.Unit == UnitDay:
+		return "daily"
+	case r.Every == 1 && r.Unit == UnitWeek:
+		rule = "weekly"
+	case r.Every == 1 && r.Unit == UnitMonth:
+		rule = "monthly"
+	default:
+		rule = fmt.Sprintf("every:%d%s", r.Every, unitCodes[r.Unit])
+	}
+
+	if len(r.Weekdays) > 0 {
+		codes := make([]string, len(r.Weekdays))
+		for i, weekday := range r.Weekdays {
+			codes[i] = weekdayShort[weekday]
+		}
+		rule += ":" + strings.Join(codes, ",")
+	}
+	if r.MonthDay > 0 {
+		rule += ":" + strconv.Itoa(r.MonthDay)
+	}
+	return rule
+}
+
+// Describe descreve a regra em português para exibição
+func (r *Recurrence) Describe() string {
+	unitNames := map[RecurrenceUnit][2]string{
+		UnitDay:   {"dia", "dias"},
+		UnitWeek:  {"semana", "semanas"},
+		UnitMonth: {"mês", "meses"},
+	}
+
+	names := unitNames[r.Unit]
+	description := "a cada " + names[0]
+	if r.Every > 1 {
+		description = fmt.Sprintf("a cada %d %s", r.Every, names[1])
+	}
+
+	if len(r.Weekdays) > 0 {
+		labels := make([]string, len(r.Weekdays))
+		for i, weekday := range r.Weekdays {
+			labels[i] = weekdayLabels[weekday]
+		}
+		description += " (" + strings.Join(labels, ", ") + ")"
+	}
+	if r.MonthDay > 0 {
+		description += fmt.Sprintf(" (dia %d)", r.MonthDay)
+	}
+	if r.AfterCompletion {
+		description += " após a conclusão"
+	}
+	return description
+}
+
+// Next calcula o próximo prazo a partir de base. O resultado é sempre
+// posterior a base e nunca anterior ao dia de now, de modo que uma série
+// atrasada não gera ocorrências já vencidas.
+func (r *Recurrence) Next(base, now time.Time) time.Time {
+	next := r.step(base)
+	for next.Before(startOfDay(now)) {
+		next = r.step(next)
+	}
+	return next
+}
+
+// step avança um intervalo da regra a partir de from
+func (r *Recurrence) step(from time.Time) time.Time {
+	every := r.Every
+	if every < 1 {
+		every = 1
+	}
+
+	switch r.Unit {
+	case UnitWeek:
+		if len(r.Weekdays) == 0 {
+			return from.AddDate(0, 0, 7*every)
+		}
+		// Próximo dia marcado na mesma semana; senão, o primeiro dia
+		// marcado da semana que fica "every" semanas à frente
+		for d := 1; int(from.Weekday())+d <= int(time.Saturday); d++ {
+			if candidate := from.AddDate(0, 0, d); containsWeekday(r.Weekdays, candidate.Weekday()) {
+				return candidate
+			}
+		}
+		weekStart := from.AddDate(0, 0, -int(from.Weekday())+7*every)
+		return weekStart.AddDate(0, 0, int(r.Weekdays[0]))
+	case UnitMonth:
+		day := r.MonthDay
+		if day == 0 {
+			// Só em regras sem dia fixo (veja anchorMonthDay)
+			day = from.Day()
+		}
+		firstOfMonth := time.Date(from.Year(), from.Month()+time.Month(every), 1,
+			from.Hour(), from.Minute(), 0, 0, from.Location())
+		return firstOfMonth.AddDate(0, 0, min(day, daysIn(firstOfMonth))-1)
+	default:
+		return from.AddDate(0, 0, every)
+	}
+}
+
+// anchorMonthDay fixa em MonthDay o dia do mês de base quando a regra
+// mensal não tem um (como "monthly"), para que os passos seguintes voltem a
+// esse dia depois de um mês mais curto. Regras contadas da conclusão
+// seguem o dia em que a tarefa foi concluída.
+func (r *Recurrence) anchorMonthDay(base time.Time) {
+	if r.Unit == UnitMonth && r.MonthDay == 0 && !r.AfterCompletion {
+		r.MonthDay = base.Day()
+	}
+}
+
+// daysIn retorna a quantidade de dias do mês de t
+func daysIn(t time.Time) int {
+	return time.Date(t.Year(), t.Month()+1, 0, 0, 0, 0, 0, t.Location()).Day()
+}
+
+// containsWeekday informa se weekday está em list
+func containsWeekday(list []time.Weekday, weekday time.Weekday) bool {
+	for _, item := range list {
+		if item == weekday {
+			return true
+		}
+	}
+	return false
+}
+
+// SetRecurrence define a regra de repetição de uma tarefa; nil remove a regra
+func (tl *TodoList) SetRecurrence(id int, recurrence *Recurrence) error {
+	task, err := tl.GetTask(id)
+	if err != nil {
+		return err
+	}
+
+	task.Recurrence = recurrence
+	if recurrence != nil && task.SeriesID == 0 {
+		task.SeriesID = task.ID
+	}
+	return nil
+}
+
+// Series retorna todas as ocorrências da série da tarefa, da mais antiga
+// para a mais recente
+func (tl *TodoList) Series(id int) []Task {
+	task, err := tl.GetTask(id)
+	if err != nil || task.SeriesID == 0 {
+		return nil
+	}
+
+	var series []Task
+	for _, t := range tl.Tasks {
+		if t.SeriesID == task.SeriesID {
+			series = append(series, t)
+		}
+	}
+	return series
+}
+
+// spawnNextOccurrence cria a próxima ocorrência de uma tarefa recorrente
+// recém-concluída. Não faz nada se a tarefa não se repete ou se a próxima
+// ocorrência já existe (ex.: tarefa reaberta e concluída de novo).
+func (tl *TodoList) spawnNextOccurrence(id int, now time.Time) {
+	done, err := tl.GetTask(id)
+	if err != nil || done.Recurrence == nil {
+		return
+	}
+	if _, err := tl.GetTask(done.NextOccurrenceID); err == nil {
+		return
+	}
+
+	base := now
+	if done.DueDate != nil && !done.Recurrence.AfterCompletion {
+		base = *done.DueDate
+	}
+	if !done.DueHasTime {
+		base = startOfDay(base)
+	}
+
+	// A série guarda o dia do mês de origem: sem isso, 31/01 viraria 28/02
+	// e, a partir daí, sempre 28
+	recurrence := *done.Recurrence
+	recurrence.anchorMonthDay(base)
+	due := recurrence.Next(base, now)
+
+	next := Task{
+		ID:          tl.NextID,
+		Title:       done.Title,
+		Description: done.Description,
+		Priority:    done.Priority,
+		DueDate:     &due,
+		DueHasTime:  done.DueHasTime,
+		Tags:        append([]string(nil), done.Tags...),
+		ProjectID:   done.ProjectID,
+		ParentID:    done.ParentID,
+		Recurrence:  &recurrence,
+		SeriesID:    done.SeriesID,
+		Rank:        tl.rankAfterTask(done),
+		CreatedAt:   now,
+	}
+	if next.SeriesID == 0 {
+		next.SeriesID = done.ID
+		done.SeriesID = done.ID
+	}
+	done.NextOccurrenceID = next.ID
+
+	tl.Tasks = append(tl.Tasks, next)
+	tl.index.add(&next)
+	tl.NextID++
+}
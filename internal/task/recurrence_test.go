@@ -0,0 +1,133 @@
+package task
+
+import (
+	"testing"
+	"time"
+)
+
+// date monta um prazo sem horário, em UTC
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestParseRecurrence(t *testing.T) {
+	tests := []struct {
+		rule string
+		want string
+	}{
+		{"daily", "daily"},
+		{"diária", "daily"},
+		{"weekly:fri,seg", "weekly:mon,fri"},
+		{"monthly", "monthly"},
+		{"mensal:31", "monthly:31"},
+		{"every:3d", "every:3d"},
+		{"every:2w:mon", "every:2w:mon"},
+		{"after:1m", "after:1m"},
+	}
+	for _, tt := range tests {
+		r, err := ParseRecurrence(tt.rule)
+		if err != nil {
+			t.Errorf("ParseRecurrence(%q): %v", tt.rule, err)
+			continue
+		}
+		if got := r.String(); got != tt.want {
+			t.Errorf("ParseRecurrence(%q).String() = %q, esperado %q", tt.rule, got, tt.want)
+		}
+	}
+
+	for _, rule := range []string{"", "yearly", "daily:mon", "monthly:32", "weekly:xyz", "every:0d", "after:1w:mon"} {
+		if _, err := ParseRecurrence(rule); err == nil {
+			t.Errorf("ParseRecurrence(%q) deveria falhar", rule)
+		}
+	}
+}
+
+func TestRecurrenceNext(t *testing.T) {
+	tests := []struct {
+		name string
+		rule string
+		base time.Time
+		want time.Time
+	}{
+		{"diária", "daily", date(2026, 12, 31), date(2027, 1, 1)},
+		{"semanal", "weekly", date(2026, 10, 14), date(2026, 10, 21)},
+		{"dias da semana", "weekly:mon,fri", date(2026, 10, 13), date(2026, 10, 16)},
+		{"dias da semana, virando a semana", "weekly:mon,fri", date(2026, 10, 16), date(2026, 10, 19)},
+		{"a cada 2 semanas", "every:2w:mon", date(2026, 10, 16), date(2026, 10, 26)},
+		{"mensal", "monthly", date(2026, 10, 15), date(2026, 11, 15)},
+		{"dia 31 em mês de 30 dias", "monthly:31", date(2027, 3, 31), date(2027, 4, 30)},
+		{"dia 31 volta depois de mês curto", "monthly:31", date(2027, 2, 28), date(2027, 3, 31)},
+		{"dia 31 em fevereiro", "monthly:31", date(2027, 1, 31), date(2027, 2, 28)},
+		{"dia 31 em fevereiro bissexto", "monthly:31", date(2028, 1, 31), date(2028, 2, 29)},
+		{"dia 29 em fevereiro não bissexto", "monthly:29", date(2027, 1, 29), date(2027, 2, 28)},
+		{"virada do ano", "monthly:31", date(2026, 12, 31), date(2027, 1, 31)},
+		{"anual a partir de 29/02", "every:12m:29", date(2028, 2, 29), date(2029, 2, 28)},
+		{"anual volta a 29/02", "every:12m:29", date(2031, 2, 28), date(2032, 2, 29)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := ParseRecurrence(tt.rule)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := r.Next(tt.base, tt.base); !got.Equal(tt.want) {
+				t.Errorf("Next(%s) = %s, esperado %s", tt.base.Format(time.DateOnly), got.Format(time.DateOnly), tt.want.Format(time.DateOnly))
+			}
+		})
+	}
+}
+
+func TestRecurrenceNextSkipsPastOccurrences(t *testing.T) {
+	r, _ := ParseRecurrence("daily")
+	now := date(2026, 10, 18).Add(15 * time.Hour)
+	if got := r.Next(date(2026, 10, 1), now); !got.Equal(date(2026, 10, 18)) {
+		t.Errorf("Next = %s, esperado o dia de hoje", got.Format(time.DateOnly))
+	}
+}
+
+func TestMonthlySeriesKeepsDayOfMonth(t *testing.T) {
+	tests := []struct {
+		name string
+		rule string
+		due  time.Time
+		want []time.Time
+	}{
+		{"fim de janeiro", "monthly", date(2027, 1, 31),
+			[]time.Time{date(2027, 2, 28), date(2027, 3, 31), date(2027, 4, 30), date(2027, 5, 31)}},
+		{"ano bissexto", "monthly", date(2028, 1, 30),
+			[]time.Time{date(2028, 2, 29), date(2028, 3, 30), date(2028, 4, 30)}},
+		{"a cada 2 meses", "every:2m", date(2026, 12, 31),
+			[]time.Time{date(2027, 2, 28), date(2027, 4, 30), date(2027, 6, 30), date(2027, 8, 31)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tl := NewTodoList()
+			task := tl.AddTask("Fechar o mês", "")
+			r, err := ParseRecurrence(tt.rule)
+			if err != nil {
+				t.Fatal(err)
+			}
+			due := tt.due
+			task.DueDate = &due
+			if err := tl.SetRecurrence(task.ID, r); err != nil {
+				t.Fatal(err)
+			}
+
+			id := task.ID
+			for i, want := range tt.want {
+				tl.complete(id, tt.due)
+				done, _ := tl.GetTask(id)
+				next, err := tl.GetTask(done.NextOccurrenceID)
+				if err != nil {
+					t.Fatalf("ocorrência %d não criada", i+1)
+				}
+				if !next.DueDate.Equal(want) {
+					t.Fatalf("ocorrência %d em %s, esperado %s", i+1, next.DueDate.Format(time.DateOnly), want.Format(time.DateOnly))
+				}
+				id = next.ID
+			}
+		})
+	}
+}
@@ -0,0 +1,257 @@
+package task
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+)
+
+// SortKey é um critério de ordenação de tarefas
+type SortKey string
+
+const (
+	SortCreated  SortKey = "created"
+	SortUpdated  SortKey = "updated"
+	SortDue      SortKey = "due"
+	SortPriority SortKey = "priority"
+	SortTitle    SortKey = "title"
+	SortStatus   SortKey = "status"
+	SortID       SortKey = "id"
+	SortRank     SortKey = "rank" // ordem manual (veja MoveTaskBefore)
+)
+
+// sortKeys lista os critérios na ordem usada nas mensagens de erro
+var sortKeys = []SortKey{SortPriority, SortDue, SortCreated, SortUpdated, SortTitle, SortStatus, SortRank, SortID}
+
+// sortKeyAliases são nomes alternativos dos critérios
+var sortKeyAliases = map[string]SortKey{
+	"prio":       SortPriority,
+	"created_at": SortCreated,
+	"updated_at": SortUpdated,
+	"manual":     SortRank,
+}
+
+// sortDescByDefault são os critérios cuja direção natural é decrescente:
+// as tarefas mais importantes e as alteradas mais recentemente primeiro
+var sortDescByDefault = map[SortKey]bool{SortPriority: true, SortUpdated: true}
+
+// SortField é um critério com a sua direção
+type SortField struct {
+	Key  SortKey
+	Desc bool
+}
+
+// SortSpec é uma ordenação com vários critérios: o segundo desempata o
+// primeiro, e assim por diante. Empates em todos mantêm a ordem recebida.
+type SortSpec []SortField
+
+// DefaultSort é a ordem padrão das listagens: por prioridade e, dentro de
+// cada prioridade, na ordem manual (que, sem movimentações, é a de criação)
+var DefaultSort = SortSpec{{Key: SortPriority, Desc: true}, {Key: SortRank}, {Key: SortID}}
+
+// Listings são as listagens que podem ter uma ordenação padrão salva
+// (veja SetListingSort)
+var Listings = []string{"list", "pending", "search", "ready"}
+
+// ParseSortSpec interpreta uma ordenação como "priority:desc,due:asc".
+// Sem direção, priority e updated são decrescentes e os demais crescentes.
+// Uma ordenação vazia retorna nil.
+func ParseSortSpec(s string) (SortSpec, error) {
+	var spec SortSpec
+	for _, part := range strings.Split(s, ",") {
+		part = strings.ToLower(strings.TrimSpace(part))
+		if part == "" {
+			continue
+		}
+
+		name, direction, hasDirection := strings.Cut(part, ":")
+		key := SortKey(name)
+		if alias, ok := sortKeyAliases[name]; ok {
+			key = alias
+		}
+		if !containsSortKey(sortKeys, key) {
+			return nil, fmt.Errorf("critério de ordenação desconhecido '%s' (use %s)", name, joinSortKeys(sortKeys))
+		}
+
+		field := SortField{Key: key, Desc: sortDescByDefault[key]}
+		if hasDirection {
+			switch direction {
+			case "asc":
+				field.Desc = false
+			case "desc":
+				field.Desc = true
+			default:
+				return nil, fmt.Errorf("direção inválida '%s' em '%s' (use asc ou desc)", direction, part)
+			}
+		}
+		spec = append(spec, field)
+	}
+	return spec, nil
+}
+
+// String formata a ordenação como aceita por ParseSortSpec
+func (s SortSpec) String() string {
+	parts := make([]string, len(s))
+	for i, field := range s {
+		direction := "asc"
+		if field.Desc {
+			direction = "desc"
+		}
+		parts[i] = string(field.Key) + ":" + direction
+	}
+	return strings.Join(parts, ",")
+}
+
+// Compare compara duas tarefas pela ordenação: negativo se a vem antes
+// de b, positivo se depois e zero se empatam em todos os critérios
+func (s SortSpec) Compare(a, b *Task) int {
+	for _, field := range s {
+		// Tarefas sem prazo ou sem posição ficam por último nas duas direções
+		if missing := compareMissing(field.Key, a, b); missing != 0 {
+			return missing
+		}
+
+		result := compareByKey(field.Key, a, b)
+		if field.Desc {
+			result = -result
+		}
+		if result != 0 {
+			return result
+		}
+	}
+	return 0
+}
+
+// Sort ordena as tarefas, mantendo a ordem recebida nos empates
+func (s SortSpec) Sort(tasks []Task) {
+	sort.SliceStable(tasks, func(i, j int) bool {
+		return s.Compare(&tasks[i], &tasks[j]) < 0
+	})
+}
+
+// SortResults ordena resultados de busca; nos empates, continua valendo
+// a relevância
+func (s SortSpec) SortResults(results []Result) {
+	sort.SliceStable(results, func(i, j int) bool {
+		return s.Compare(&results[i].Task, &results[j].Task) < 0
+	})
+}
+
+// SetListingSort salva a ordenação padrão de uma listagem, validada e
+// normalizada. Uma ordenação vazia volta à ordem padrão da listagem.
+func (tl *TodoList) SetListingSort(listing, sortSpec string) error {
+	if !slices.Contains(Listings, listing) {
+		return fmt.Errorf("listagem desconhecida '%s' (use %s)", listing, strings.Join(Listings, ", "))
+	}
+	spec, err := ParseSortSpec(sortSpec)
+	if err != nil {
+		return err
+	}
+
+	if len(spec) == 0 {
+		delete(tl.ListingSorts, listing)
+		if len(tl.ListingSorts) == 0 {
+			tl.ListingSorts = nil
+		}
+		return nil
+	}
+	if tl.ListingSorts == nil {
+		tl.ListingSorts = make(map[string]string)
+	}
+	tl.ListingSorts[listing] = spec.String()
+	return nil
+}
+
+// ListingSort retorna a ordenação salva para a listagem, ou nil se ela usa
+// a ordem padrão. Uma ordenação inválida, como a de um arquivo editado à
+// mão, também retorna nil.
+func (tl *TodoList) ListingSort(listing string) SortSpec {
+	spec, err := ParseSortSpec(tl.ListingSorts[listing])
+	if err != nil {
+		return nil
+	}
+	return spec
+}
+
+// compareByKey compara duas tarefas por um critério, em ordem crescente
+func compareByKey(key SortKey, a, b *Task) int {
+	switch key {
+	case SortCreated:
+		return a.CreatedAt.Compare(b.CreatedAt)
+	case SortUpdated:
+		// Tarefas nunca alteradas contam a partir da criação
+		return lastChange(a).Compare(lastChange(b))
+	case SortDue:
+		if a.DueDate == nil || b.DueDate == nil {
+			return 0
+		}
+		return a.DueDate.Compare(*b.DueDate)
+	case SortRank:
+		return strings.Compare(a.Rank, b.Rank)
+	case SortPriority:
+		return cmp.Compare(a.Priority, b.Priority)
+	case SortTitle:
+		return strings.Compare(string(foldRunes(a.Title)), string(foldRunes(b.Title)))
+	case SortStatus:
+		// Crescente: pendentes antes das concluídas
+		return compareBools(a.Completed, b.Completed)
+	case SortID:
+		return cmp.Compare(a.ID, b.ID)
+	}
+	return 0
+}
+
+// compareMissing põe depois a tarefa sem o campo do critério, quando só
+// uma delas o tem
+func compareMissing(key SortKey, a, b *Task) int {
+	var aMissing, bMissing bool
+	switch key {
+	case SortDue:
+		aMissing, bMissing = a.DueDate == nil, b.DueDate == nil
+	case SortRank:
+		aMissing, bMissing = a.Rank == "", b.Rank == ""
+	}
+	return compareBools(aMissing, bMissing)
+}
+
+// lastChange retorna quando a tarefa foi alterada pela última vez
+func lastChange(t *Task) time.Time {
+	if t.UpdatedAt != nil {
+		return *t.UpdatedAt
+	}
+	return t.CreatedAt
+}
+
+// compareBools ordena false antes de true
+func compareBools(a, b bool) int {
+	switch {
+	case a == b:
+		return 0
+	case !a:
+		return -1
+	default:
+		return 1
+	}
+}
+
+// containsSortKey informa se a lista contém o critério
+func containsSortKey(keys []SortKey, key SortKey) bool {
+	for _, k := range keys {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
+
+// joinSortKeys formata os critérios para mensagens de erro
+func joinSortKeys(keys []SortKey) string {
+	names := make([]string, len(keys))
+	for i, key := range keys {
+		names[i] = string(key)
+	}
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,144 @@
+package task
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseSortSpec(t *testing.T) {
+	for input, want := range map[string]string{
+		"":                             "",
+		" , ":                          "",
+		"priority":                     "priority:desc",
+		"updated":                      "updated:desc",
+		"due,title":                    "due:asc,title:asc",
+		"PRIO:asc, Due:DESC":           "priority:asc,due:desc",
+		"created_at,updated_at,manual": "created:asc,updated:desc,rank:asc",
+		"status:desc,id":               "status:desc,id:asc",
+	} {
+		spec, err := ParseSortSpec(input)
+		if err != nil {
+			t.Errorf("%q: %v", input, err)
+			continue
+		}
+		if spec.String() != want {
+			t.Errorf("%q: %q, esperado %q", input, spec.String(), want)
+		}
+		// String gera uma ordenação que volta a ser interpretada igual
+		if again, err := ParseSortSpec(spec.String()); err != nil || !reflect.DeepEqual(again, spec) {
+			t.Errorf("%q: relida como %v, erro %v", spec.String(), again, err)
+		}
+	}
+
+	for input, want := range map[string]string{
+		"tamanho":        "critério de ordenação desconhecido 'tamanho'",
+		"due:up":         "direção inválida 'up' em 'due:up'",
+		"title,prazo":    "critério de ordenação desconhecido 'prazo'",
+		"priority:desc:": "direção inválida 'desc:'",
+	} {
+		if _, err := ParseSortSpec(input); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("%q: erro %v, esperado %q", input, err, want)
+		}
+	}
+}
+
+func TestSortSpecMultipleKeys(t *testing.T) {
+	tl := NewTodoList()
+	for _, title := range []string{"Deploy", "backup", "Ação", "relatório", "deploy"} {
+		tl.AddTask(title, "")
+	}
+	soon, later := date(2026, 10, 20), date(2026, 11, 1)
+	tl.Tasks[0].Priority, tl.Tasks[0].DueDate = PriorityHigh, &later
+	tl.Tasks[1].Priority, tl.Tasks[1].DueDate = PriorityHigh, &soon
+	tl.Tasks[2].Priority = PriorityHigh
+	tl.Tasks[3].DueDate = &soon
+	tl.Tasks[4].Priority, tl.Tasks[4].DueDate = PriorityHigh, &later
+	if err := tl.ToggleTask(5); err != nil {
+		t.Fatal(err)
+	}
+
+	for input, want := range map[string][]int{
+		// Prioridade desempatada pelo prazo; sem prazo vai por último, e
+		// empates em tudo mantêm a ordem recebida
+		"priority,due": {2, 1, 5, 3, 4},
+		"due:desc,id":  {1, 5, 2, 4, 3},
+		// Títulos sem diferenciar maiúsculas e acentos
+		"title,id:desc":           {3, 2, 5, 1, 4},
+		"status,priority,id:desc": {3, 2, 1, 4, 5},
+	} {
+		spec, err := ParseSortSpec(input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		tasks := append([]Task(nil), tl.Tasks...)
+		spec.Sort(tasks)
+		if !reflect.DeepEqual(ids(tasks), want) {
+			t.Errorf("%q: %v, esperado %v", input, ids(tasks), want)
+		}
+	}
+
+	// A ordenação padrão segue a prioridade e, dentro dela, a ordem manual
+	if err := tl.MoveTaskBefore(3, 1); err != nil {
+		t.Fatal(err)
+	}
+	tasks := append([]Task(nil), tl.Tasks...)
+	DefaultSort.Sort(tasks)
+	if want := []int{3, 1, 2, 5, 4}; !reflect.DeepEqual(ids(tasks), want) {
+		t.Errorf("ordenação padrão: %v, esperado %v", ids(tasks), want)
+	}
+}
+
+func TestListingSort(t *testing.T) {
+	tl := NewTodoList()
+	h := NewHistory(DefaultHistoryDepth)
+	record(t, h, tl, func() error { return tl.SetListingSort("pending", "DUE, prio") })
+
+	if got := tl.ListingSort("pending").String(); got != "due:asc,priority:desc" {
+		t.Errorf("ordenação salva: %q", got)
+	}
+	if spec := tl.ListingSort("list"); spec != nil {
+		t.Errorf("listagem sem ordenação salva: %v", spec)
+	}
+	if err := tl.SetListingSort("agenda", "due"); err == nil {
+		t.Error("listagem desconhecida aceita")
+	}
+	if err := tl.SetListingSort("list", "tamanho"); err == nil || tl.ListingSorts["list"] != "" {
+		t.Errorf("ordenação inválida: erro %v, salvas %v", err, tl.ListingSorts)
+	}
+
+	// Voltar à ordem padrão apaga a entrada, e a alteração pode ser desfeita
+	record(t, h, tl, func() error { return tl.SetListingSort("pending", "") })
+	if tl.ListingSorts != nil {
+		t.Errorf("ordenações após voltar ao padrão: %v", tl.ListingSorts)
+	}
+	op, err := h.UndoLast(tl)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if op.Label != "alterar ordenação padrão" || tl.ListingSort("pending").String() != "due:asc,priority:desc" {
+		t.Errorf("desfazer %q: %v", op.Label, tl.ListingSorts)
+	}
+
+	// Um arquivo editado à mão com uma ordenação inválida usa a padrão
+	tl.ListingSorts["search"] = "tamanho"
+	if spec := tl.ListingSort("search"); spec != nil {
+		t.Errorf("ordenação inválida lida como %v", spec)
+	}
+}
+
+// Cada lado mantém as ordenações que alterou; nas demais vale a do outro
+func TestMergeListingSorts(t *testing.T) {
+	base, ours, theirs := diverge(t, "deploy")
+	for _, tl := range []*TodoList{base, ours, theirs} {
+		tl.ListingSorts = map[string]string{"list": "title:asc", "search": "due:asc"}
+	}
+	ours.ListingSorts = map[string]string{"list": "id:asc", "search": "due:asc"}
+	theirs.ListingSorts = map[string]string{"list": "title:desc", "ready": "rank:asc"}
+
+	merged, _ := Merge(base, ours, theirs)
+	want := map[string]string{"list": "id:asc", "ready": "rank:asc"}
+	if !reflect.DeepEqual(merged.ListingSorts, want) {
+		t.Errorf("ordenações mescladas: %v, esperado %v", merged.ListingSorts, want)
+	}
+}
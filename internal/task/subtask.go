@@ -0,0 +1,210 @@
+package task
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	// ErrOpenSubtasks indica que a tarefa não pode ser concluída porque
+	// ainda tem subtarefas pendentes
+	ErrOpenSubtasks = errors.New("tarefa possui subtarefas pendentes")
+
+	// ErrHasSubtasks indica que a tarefa não pode ser removida sozinha
+	// porque possui subtarefas
+	ErrHasSubtasks = errors.New("tarefa possui subtarefas")
+)
+
+// TreeItem é uma tarefa posicionada na árvore de tarefas e subtarefas
+type TreeItem struct {
+	Task  Task
+	Depth int
+}
+
+// SubtaskProgress resume o andamento das subtarefas diretas de uma tarefa
+type SubtaskProgress struct {
+	Task  Task
+	Done  int
+	Total int
+}
+
+// IsSubtask informa se a tarefa pertence a uma tarefa principal
+func (t *Task) IsSubtask() bool {
+	return t.ParentID != 0
+}
+
+// AddSubtask cria uma subtarefa no mesmo projeto da tarefa principal
+func (tl *TodoList) AddSubtask(parentID int, title, description string) (*Task, error) {
+	parent, err := tl.GetTask(parentID)
+	if err != nil {
+		return nil, err
+	}
+	projectID := parent.ProjectID
+
+	subtask := tl.AddTask(title, description)
+	subtask.ParentID = parentID
+	subtask.ProjectID = projectID
+	return subtask, nil
+}
+
+// SetParent transforma a tarefa em subtarefa de parentID. Um parentID zero
+// torna a tarefa independente novamente.
+func (tl *TodoList) SetParent(id, parentID int) error {
+	task, err := tl.GetTask(id)
+	if err != nil {
+		return err
+	}
+
+	if parentID == 0 {
+		task.ParentID = 0
+		return nil
+	}
+
+	parent, err := tl.GetTask(parentID)
+	if err != nil {
+		return err
+	}
+	if parentID == id || containsInt(tl.descendantIDs(id), parentID) {
+		return fmt.Errorf("tarefa [%d] não pode ser subtarefa de [%d]: criaria um ciclo", id, parentID)
+	}
+
+	task.ParentID = parentID
+	for _, childID := range append([]int{id}, tl.descendantIDs(id)...) {
+		child, _ := tl.GetTask(childID)
+		child.ProjectID = parent.ProjectID
+	}
+	return nil
+}
+
+// Children retorna as subtarefas diretas de uma tarefa
+func (tl *TodoList) Children(id int) []Task {
+	var children []Task
+	for _, task := range tl.Tasks {
+		if task.ParentID == id {
+			children = append(children, task)
+		}
+	}
+	return children
+}
+
+// OpenSubtasks retorna todas as subtarefas pendentes (em qualquer nível)
+func (tl *TodoList) OpenSubtasks(id int) []Task {
+	var open []Task
+	for _, childID := range tl.descendantIDs(id) {
+		child, _ := tl.GetTask(childID)
+		if !child.Completed {
+			open = append(open, *child)
+		}
+	}
+	return open
+}
+
+// Progress retorna quantas subtarefas diretas estão concluídas e o total
+func (tl *TodoList) Progress(id int) (done, total int) {
+	for _, child := range tl.Children(id) {
+		total++
+		if child.Completed {
+			done++
+		}
+	}
+	return done, total
+}
+
+// ParentsProgress retorna o andamento de cada tarefa que possui subtarefas
+func (tl *TodoList) ParentsProgress() []SubtaskProgress {
+	var progress []SubtaskProgress
+	for _, task := range tl.Tasks {
+		done, total := tl.Progress(task.ID)
+		if total == 0 {
+			continue
+		}
+		progress = append(progress, SubtaskProgress{Task: task, Done: done, Total: total})
+	}
+	return progress
+}
+
+// CompleteWithSubtasks conclui a tarefa e todas as suas subtarefas pendentes
+func (tl *TodoList) CompleteWithSubtasks(id int) error {
+	if _, err := tl.GetTask(id); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for _, childID := range tl.descendantIDs(id) {
+		tl.complete(childID, now)
+	}
+	tl.complete(id, now)
+	return nil
+}
+
+// RemoveTaskTree move para a lixeira a tarefa e todas as suas subtarefas,
+// removendo as dependências que apontam para elas
+func (tl *TodoList) RemoveTaskTree(id int) error {
+	if _, err := tl.GetTask(id); err != nil {
+		return err
+	}
+
+	tl.moveToTrash(append(tl.descendantIDs(id), id), time.Now())
+	return nil
+}
+
+// BuildTree organiza as tarefas em árvore, em profundidade, mantendo a ordem
+// recebida entre irmãs. Subtarefas cuja tarefa principal não está em tasks
+// aparecem como raiz.
+func BuildTree(tasks []Task) []TreeItem {
+	present := make(map[int]bool, len(tasks))
+	for _, task := range tasks {
+		present[task.ID] = true
+	}
+
+	children := make(map[int][]Task)
+	var roots []Task
+	for _, task := range tasks {
+		if task.ParentID != 0 && present[task.ParentID] {
+			children[task.ParentID] = append(children[task.ParentID], task)
+			continue
+		}
+		roots = append(roots, task)
+	}
+
+	items := make([]TreeItem, 0, len(tasks))
+	var walk func(task Task, depth int)
+	walk = func(task Task, depth int) {
+		items = append(items, TreeItem{Task: task, Depth: depth})
+		for _, child := range children[task.ID] {
+			walk(child, depth+1)
+		}
+	}
+	for _, root := range roots {
+		walk(root, 0)
+	}
+	return items
+}
+
+// descendantIDs retorna os IDs de todas as subtarefas, em qualquer nível
+func (tl *TodoList) descendantIDs(id int) []int {
+	var ids []int
+	queue := []int{id}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, task := range tl.Tasks {
+			if task.ParentID == current && !containsInt(ids, task.ID) {
+				ids = append(ids, task.ID)
+				queue = append(queue, task.ID)
+			}
+		}
+	}
+	return ids
+}
+
+// containsInt informa se n está em list
+func containsInt(list []int, n int) bool {
+	for _, item := range list {
+		if item == n {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,189 @@
+package task
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// TagCount associa uma tag à quantidade de tarefas que a utilizam
+type TagCount struct {
+	Tag   string
+	Count int
+}
+
+// NormalizeTag padroniza uma tag: remove o "#" inicial e espaços e converte
+// para minúsculas. Tags podem conter letras, números, "-", "_", "/" e ".".
+func NormalizeTag(tag string) (string, error) {
+	tag = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(tag), "#"))
+	if tag == "" {
+		return "", fmt.Errorf("tag não pode ser vazia")
+	}
+
+	for _, r := range tag {
+		if !isTagRune(r) {
+			return "", fmt.Errorf("tag inválida: %s", tag)
+		}
+	}
+	return tag, nil
+}
+
+// isTagRune informa se o caractere é permitido em uma tag
+func isTagRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || strings.ContainsRune("-_/.", r)
+}
+
+// ExtractTags separa os tokens "#tag" de um texto. Retorna o texto sem as
+// tags e a lista de tags normalizadas. Tokens como "#123", que não começam
+// com letra, são mantidos no texto.
+func ExtractTags(text string) (string, []string) {
+	var words, tags []string
+	for _, word := range strings.Fields(text) {
+		runes := []rune(word)
+		if len(runes) < 2 || runes[0] != '#' || !unicode.IsLetter(runes[1]) {
+			words = append(words, word)
+			continue
+		}
+
+		tag, err := NormalizeTag(word)
+		if err != nil {
+			words = append(words, word)
+			continue
+		}
+		tags = append(tags, tag)
+	}
+	return strings.Join(words, " "), tags
+}
+
+// HasTag informa se a tarefa possui a tag
+func (t *Task) HasTag(tag string) bool {
+	for _, existing := range t.Tags {
+		if existing == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// HasAllTags informa se a tarefa possui todas as tags informadas
+func (t *Task) HasAllTags(tags []string) bool {
+	for _, tag := range tags {
+		if !t.HasTag(tag) {
+			return false
+		}
+	}
+	return true
+}
+
+// addTags adiciona tags já normalizadas, ignorando repetidas
+func (t *Task) addTags(tags []string) {
+	for _, tag := range tags {
+		if !t.HasTag(tag) {
+			t.Tags = append(t.Tags, tag)
+		}
+	}
+	sort.Strings(t.Tags)
+}
+
+// AddTags adiciona tags a uma tarefa
+func (tl *TodoList) AddTags(id int, tags ...string) error {
+	normalized, err := normalizeTags(tags)
+	if err != nil {
+		return err
+	}
+
+	task, err := tl.GetTask(id)
+	if err != nil {
+		return err
+	}
+
+	task.addTags(normalized)
+	return nil
+}
+
+// RemoveTags remove tags de uma tarefa. Tags inexistentes são ignoradas.
+func (tl *TodoList) RemoveTags(id int, tags ...string) error {
+	normalized, err := normalizeTags(tags)
+	if err != nil {
+		return err
+	}
+
+	task, err := tl.GetTask(id)
+	if err != nil {
+		return err
+	}
+
+	kept := task.Tags[:0]
+	for _, existing := range task.Tags {
+		if !containsString(normalized, existing) {
+			kept = append(kept, existing)
+		}
+	}
+	task.Tags = kept
+	if len(task.Tags) == 0 {
+		task.Tags = nil
+	}
+	return nil
+}
+
+// TagCounts retorna as tags em uso com a quantidade de tarefas de cada uma,
+// das mais usadas para as menos usadas
+func (tl *TodoList) TagCounts() []TagCount {
+	counts := make(map[string]int)
+	for _, task := range tl.Tasks {
+		for _, tag := range task.Tags {
+			counts[tag]++
+		}
+	}
+
+	result := make([]TagCount, 0, len(counts))
+	for tag, count := range counts {
+		result = append(result, TagCount{Tag: tag, Count: count})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Count != result[j].Count {
+			return result[i].Count > result[j].Count
+		}
+		return result[i].Tag < result[j].Tag
+	})
+	return result
+}
+
+// FilterByTags retorna as tarefas que possuem todas as tags informadas
+func FilterByTags(tasks []Task, tags []string) []Task {
+	if len(tags) == 0 {
+		return tasks
+	}
+
+	var result []Task
+	for _, task := range tasks {
+		if task.HasAllTags(tags) {
+			result = append(result, task)
+		}
+	}
+	return result
+}
+
+// normalizeTags normaliza uma lista de tags
+func normalizeTags(tags []string) ([]string, error) {
+	normalized := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		n, err := NormalizeTag(tag)
+		if err != nil {
+			return nil, err
+		}
+		normalized = append(normalized, n)
+	}
+	return normalized, nil
+}
+
+// containsString informa se s está em list
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
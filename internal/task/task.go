@@ -1,18 +1,47 @@
 package task
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 	"time"
 )
 
+// ErrTaskNotFound indica que não existe tarefa com o ID informado
+var ErrTaskNotFound = errors.New("tarefa não encontrada")
+
+// notFound monta o erro de tarefa inexistente para um ID
+func notFound(id int) error {
+	return fmt.Errorf("%w: ID %d", ErrTaskNotFound, id)
+}
+
 // Task representa uma tarefa individual
 type Task struct {
-	ID          int       `json:"id"`
-	Title       string    `json:"title"`
-	Description string    `json:"description"`
-	Completed   bool      `json:"completed"`
-	CreatedAt   time.Time `json:"created_at"`
+	ID          int         `json:"id"`
+	Title       string      `json:"title"`
+	Description string      `json:"description"`
+	Completed   bool        `json:"completed"`
+	Priority    Priority    `json:"priority,omitempty"`
+	DueDate     *time.Time  `json:"due_date,omitempty"`
+	DueHasTime  bool        `json:"due_has_time,omitempty"`
+	Tags        []string    `json:"tags,omitempty"`
+	ProjectID   int         `json:"project_id,omitempty"`
+	ParentID    int         `json:"parent_id,omitempty"`
+	BlockedBy   []int       `json:"blocked_by,omitempty"`
+	Recurrence  *Recurrence `json:"recurrence,omitempty"`
+	SeriesID    int         `json:"series_id,omitempty"`
+	Rank        string      `json:"rank,omitempty"` // posição na ordem manual (veja rank.go)
+
+	// NextOccurrenceID aponta para a ocorrência criada ao concluir uma
+	// tarefa recorrente
+	NextOccurrenceID int        `json:"next_occurrence_id,omitempty"`
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        *time.Time `json:"updated_at,omitempty"`
+	CompletedAt      *time.Time `json:"completed_at,omitempty"`
+	DeletedAt        *time.Time `json:"deleted_at,omitempty"` // preenchido na lixeira
+
+	// Events é o histórico de auditoria da tarefa (veja Track)
+	Events []Event `json:"events,omitempty"`
 }
 
 // String implementa a interface Stringer para formatação
@@ -25,10 +54,22 @@ func (t *Task) String() string {
 		t.ID, t.Title, t.Description, status)
 }
 
-// TodoList gerencia uma coleção de tasks
+// TodoList gerencia uma coleção de tasks. Tarefas removidas ficam em
+// Trash até serem apagadas definitivamente.
 type TodoList struct {
-	Tasks  []Task `json:"tasks"`
-	NextID int    `json:"next_id"`
+	Tasks         []Task    `json:"tasks"`
+	NextID        int       `json:"next_id"`
+	Projects      []Project `json:"projects,omitempty"`
+	NextProjectID int       `json:"next_project_id,omitempty"`
+	Trash         []Task    `json:"trash,omitempty"`
+	Views         []View    `json:"views,omitempty"`
+
+	// ListingSorts guarda a ordenação padrão escolhida para cada listagem
+	// (veja SetListingSort)
+	ListingSorts map[string]string `json:"listing_sorts,omitempty"`
+
+	// index acelera as buscas de texto (veja index.go)
+	index *searchIndex
 }
 
 // NewTodoList cria uma nova lista de tarefas
@@ -39,42 +80,109 @@ func NewTodoList() *TodoList {
 	}
 }
 
-// AddTask adiciona uma nova tarefa à lista
+// AddTask adiciona uma nova tarefa à lista. Tokens "#tag" no título
+// são extraídos para as tags da tarefa.
 func (tl *TodoList) AddTask(title, description string) *Task {
+	title, tags := splitTitleTags(title)
+
 	task := Task{
 		ID:          tl.NextID,
 		Title:       title,
 		Description: description,
 		Completed:   false,
+		Rank:        tl.nextRank(),
 		CreatedAt:   time.Now(),
 	}
+	task.addTags(tags)
 
 	tl.Tasks = append(tl.Tasks, task)
+	tl.index.add(&task)
 	tl.NextID++
 
-	return &task
+	return &tl.Tasks[len(tl.Tasks)-1]
 }
 
-// ToggleTask alterna o status de uma tarefa
+// ToggleTask alterna o status de uma tarefa. Uma tarefa com subtarefas
+// pendentes não pode ser concluída (veja CompleteWithSubtasks). Concluir
+// uma tarefa recorrente cria a sua próxima ocorrência.
 func (tl *TodoList) ToggleTask(id int) error {
-	for i := range tl.Tasks {
-		if tl.Tasks[i].ID == id {
-			tl.Tasks[i].Completed = !tl.Tasks[i].Completed
-			return nil
-		}
+	task, err := tl.GetTask(id)
+	if err != nil {
+		return err
+	}
+
+	if task.Completed {
+		task.Completed = false
+		task.CompletedAt = nil
+		return nil
 	}
-	return fmt.Errorf("tarefa com ID %d não encontrada", id)
+
+	if open := tl.OpenSubtasks(id); len(open) > 0 {
+		return fmt.Errorf("%w: %d em aberto", ErrOpenSubtasks, len(open))
+	}
+	tl.complete(id, time.Now())
+	return nil
 }
 
-// RemoveTask remove uma tarefa da lista
+// complete marca a tarefa como concluída e, se for recorrente, cria a
+// próxima ocorrência. Ponteiros para tarefas da lista ficam inválidos.
+func (tl *TodoList) complete(id int, now time.Time) {
+	task, err := tl.GetTask(id)
+	if err != nil || task.Completed {
+		return
+	}
+
+	task.Completed = true
+	task.CompletedAt = &now
+	tl.spawnNextOccurrence(id, now)
+}
+
+// RemoveTask move uma tarefa para a lixeira e remove as dependências que
+// apontam para ela. Tarefas com subtarefas precisam ser removidas com
+// RemoveTaskTree.
 func (tl *TodoList) RemoveTask(id int) error {
-	for i, task := range tl.Tasks {
-		if task.ID == id {
-			tl.Tasks = append(tl.Tasks[:i], tl.Tasks[i+1:]...)
-			return nil
-		}
+	if _, err := tl.GetTask(id); err != nil {
+		return err
+	}
+	if children := tl.Children(id); len(children) > 0 {
+		return fmt.Errorf("%w: %d subtarefa(s)", ErrHasSubtasks, len(children))
+	}
+
+	tl.moveToTrash([]int{id}, time.Now())
+	return nil
+}
+
+// EditTask altera título e descrição de uma tarefa existente. Assim como
+// em AddTask, tokens "#tag" no título são adicionados às tags. Para editar
+// outros campos, veja UpdateTask.
+func (tl *TodoList) EditTask(id int, title, description string) error {
+	_, err := tl.UpdateTask(id, TaskUpdate{Title: &title, Description: &description})
+	return err
+}
+
+// splitTitleTags extrai as tags do título. Se o título for composto apenas
+// por tags, ele é mantido como está para não ficar vazio.
+func splitTitleTags(title string) (string, []string) {
+	clean, tags := ExtractTags(title)
+	if clean == "" {
+		return strings.TrimSpace(title), tags
+	}
+	return clean, tags
+}
+
+// SetPriority define a prioridade de uma tarefa
+func (tl *TodoList) SetPriority(id int, priority Priority) error {
+	if !priority.Valid() {
+		return fmt.Errorf("prioridade inválida: %d", int(priority))
+	}
+
+	task, err := tl.GetTask(id)
+	if err != nil {
+		return err
 	}
-	return fmt.Errorf("tarefa com ID %d não encontrada", id)
+
+	task.Priority = priority
+	return nil
 }
 
 // GetTask retorna uma tarefa por ID
@@ -84,10 +192,18 @@ func (tl *TodoList) GetTask(id int) (*Task, error) {
 			return &tl.Tasks[i], nil
 		}
 	}
-	return nil, fmt.Errorf("tarefa com ID %d não encontrada", id)
+	return nil, notFound(id)
 }
 
-// ListPendingTasks retorna apenas tarefas pendentes
+// SortedTasks retorna uma cópia das tarefas ordenada por prioridade e posição (veja DefaultSort)
+func (tl *TodoList) SortedTasks() []Task {
+	sorted := make([]Task, len(tl.Tasks))
+	copy(sorted, tl.Tasks)
+	SortByPriority(sorted)
+	return sorted
+}
+
+// ListPendingTasks retorna apenas tarefas pendentes, ordenadas por prioridade e posição
 func (tl *TodoList) ListPendingTasks() []Task {
 	var pending []Task
 	for _, task := range tl.Tasks {
@@ -95,23 +211,25 @@ func (tl *TodoList) ListPendingTasks() []Task {
 			pending = append(pending, task)
 		}
 	}
+	SortByPriority(pending)
 	return pending
 }
 
-// SearchTasks busca tarefas que contenham o termo no título ou descrição
+// SearchTasks busca tarefas que satisfazem a consulta (veja ParseQuery),
+// das mais às menos relevantes. Uma consulta com erro de sintaxe é buscada
+// como texto simples no título e na descrição.
 func (tl *TodoList) SearchTasks(query string) []Task {
-	var results []Task
-	query = strings.ToLower(query)
-
-	for _, task := range tl.Tasks {
-		title := strings.ToLower(task.Title)
-		description := strings.ToLower(task.Description)
-
-		if strings.Contains(title, query) || strings.Contains(description, query) {
-			results = append(results, task)
-		}
+	now := time.Now()
+	parsed, err := ParseQuery(query, now)
+	if err != nil {
+		parsed = &Query{Source: query, Root: &TextNode{Text: query}}
+	}
+	results := parsed.Rank(tl, tl.Tasks, now)
+	tasks := make([]Task, len(results))
+	for i, result := range results {
+		tasks[i] = result.Task
 	}
-	return results
+	return tasks
 }
 
 // Stats retorna estatísticas da lista
@@ -0,0 +1,149 @@
+package task
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// DefaultTrashRetentionDays é por quantos dias uma tarefa removida fica na
+// lixeira antes de ser apagada definitivamente
+const DefaultTrashRetentionDays = 30
+
+// ErrNotInTrash indica que a tarefa informada não está na lixeira
+var ErrNotInTrash = errors.New("tarefa não está na lixeira")
+
+// IsDeleted informa se a tarefa está na lixeira
+func (t *Task) IsDeleted() bool {
+	return t.DeletedAt != nil
+}
+
+// moveToTrash tira as tarefas da lista e as guarda na lixeira com a data
+// de remoção. Os IDs continuam reservados: NextID nunca é reduzido.
+func (tl *TodoList) moveToTrash(ids []int, now time.Time) {
+	kept := tl.Tasks[:0]
+	for _, task := range tl.Tasks {
+		if !containsInt(ids, task.ID) {
+			kept = append(kept, task)
+			continue
+		}
+		deletedAt := now
+		task.DeletedAt = &deletedAt
+		tl.Trash = append(tl.Trash, task)
+		tl.index.remove(task.ID)
+	}
+	tl.Tasks = kept
+	tl.dropDependencies(ids...)
+}
+
+// TrashedTasks retorna as tarefas da lixeira, das removidas mais
+// recentemente para as mais antigas
+func (tl *TodoList) TrashedTasks() []Task {
+	trashed := make([]Task, len(tl.Trash))
+	copy(trashed, tl.Trash)
+	sort.SliceStable(trashed, func(i, j int) bool {
+		return trashed[i].DeletedAt.After(*trashed[j].DeletedAt)
+	})
+	return trashed
+}
+
+// RestoreTask devolve à lista uma tarefa da lixeira, junto com as suas
+// subtarefas que também estão na lixeira. Referências a tarefas que não
+// existem mais (tarefa principal, dependências) são descartadas. Retorna
+// os IDs restaurados.
+func (tl *TodoList) RestoreTask(id int) ([]int, error) {
+	if tl.trashIndex(id) < 0 {
+		if _, err := tl.GetTask(id); err == nil {
+			return nil, fmt.Errorf("%w: [%d] não foi removida", ErrNotInTrash, id)
+		}
+		return nil, notFound(id)
+	}
+
+	restore := append([]int{id}, tl.trashedDescendantIDs(id)...)
+	kept := tl.Trash[:0]
+	for _, task := range tl.Trash {
+		if !containsInt(restore, task.ID) {
+			kept = append(kept, task)
+			continue
+		}
+		task.DeletedAt = nil
+		tl.Tasks = append(tl.Tasks, task)
+		tl.index.add(&task)
+		tl.NextID = max(tl.NextID, task.ID+1)
+	}
+	tl.Trash = kept
+	sort.SliceStable(tl.Tasks, func(i, j int) bool { return tl.Tasks[i].ID < tl.Tasks[j].ID })
+
+	for _, restoredID := range restore {
+		task, _ := tl.GetTask(restoredID)
+		if _, err := tl.GetTask(task.ParentID); task.ParentID != 0 && err != nil {
+			task.ParentID = 0
+		}
+		var blockedBy []int
+		for _, blockerID := range task.BlockedBy {
+			if _, err := tl.GetTask(blockerID); err == nil {
+				blockedBy = append(blockedBy, blockerID)
+			}
+		}
+		task.BlockedBy = blockedBy
+	}
+	return restore, nil
+}
+
+// PurgeTask apaga definitivamente uma tarefa da lixeira
+func (tl *TodoList) PurgeTask(id int) error {
+	i := tl.trashIndex(id)
+	if i < 0 {
+		return fmt.Errorf("%w: ID %d", ErrNotInTrash, id)
+	}
+	tl.Trash = append(tl.Trash[:i], tl.Trash[i+1:]...)
+	return nil
+}
+
+// PurgeTrash apaga definitivamente as tarefas removidas antes de before e
+// retorna quantas foram apagadas. Um before zero esvazia a lixeira.
+func (tl *TodoList) PurgeTrash(before time.Time) int {
+	kept := tl.Trash[:0]
+	for _, task := range tl.Trash {
+		if before.IsZero() || task.DeletedAt.Before(before) {
+			continue
+		}
+		kept = append(kept, task)
+	}
+
+	purged := len(tl.Trash) - len(kept)
+	tl.Trash = kept
+	if len(tl.Trash) == 0 {
+		tl.Trash = nil
+	}
+	return purged
+}
+
+// trashIndex retorna a posição da tarefa na lixeira, ou -1
+func (tl *TodoList) trashIndex(id int) int {
+	for i := range tl.Trash {
+		if tl.Trash[i].ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// trashedDescendantIDs retorna as subtarefas de id, em qualquer nível, que
+// estão na lixeira
+func (tl *TodoList) trashedDescendantIDs(id int) []int {
+	var ids []int
+	queue := []int{id}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, task := range tl.Trash {
+			if task.ParentID == current && !containsInt(ids, task.ID) {
+				ids = append(ids, task.ID)
+				queue = append(queue, task.ID)
+			}
+		}
+	}
+	return ids
+}
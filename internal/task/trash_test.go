@@ -0,0 +1,151 @@
+package task
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// trashed lista os IDs da lixeira, dos removidos mais recentemente para os
+// mais antigos
+func trashed(tl *TodoList) []int {
+	return ids(tl.TrashedTasks())
+}
+
+func TestRemoveMovesToTrash(t *testing.T) {
+	tl := NewTodoList()
+	tl.AddTask("deploy", "")
+	tl.AddTask("backup", "")
+	if err := tl.AddDependency(1, 2); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := tl.RemoveTask(2); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(ids(tl.Tasks), []int{1}) || !reflect.DeepEqual(trashed(tl), []int{2}) {
+		t.Fatalf("lista %v, lixeira %v", ids(tl.Tasks), trashed(tl))
+	}
+	if !tl.Trash[0].IsDeleted() {
+		t.Error("tarefa da lixeira sem data de remoção")
+	}
+	if len(tl.Tasks[0].BlockedBy) > 0 {
+		t.Errorf("dependência da tarefa removida mantida: %v", tl.Tasks[0].BlockedBy)
+	}
+	if _, err := tl.GetTask(2); err == nil {
+		t.Error("tarefa da lixeira ainda encontrada na lista")
+	}
+
+	// O ID da tarefa removida continua reservado
+	if added := tl.AddTask("nova", ""); added.ID != 3 {
+		t.Errorf("tarefa nova com ID %d, esperado 3", added.ID)
+	}
+}
+
+func TestRemoveTaskWithSubtasks(t *testing.T) {
+	tl := NewTodoList()
+	tl.AddTask("release", "")
+	child, err := tl.AddSubtask(1, "changelog", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tl.AddSubtask(child.ID, "revisar", ""); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := tl.RemoveTask(1); !errors.Is(err, ErrHasSubtasks) {
+		t.Fatalf("remover tarefa com subtarefas: erro %v, esperado ErrHasSubtasks", err)
+	}
+	if err := tl.RemoveTaskTree(1); err != nil {
+		t.Fatal(err)
+	}
+	if len(tl.Tasks) != 0 || len(tl.Trash) != 3 {
+		t.Fatalf("lista %v, lixeira %v", ids(tl.Tasks), ids(tl.Trash))
+	}
+
+	// Restaurar a tarefa principal traz a árvore inteira de volta
+	restored, err := tl.RestoreTask(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(restored, []int{1, 2, 3}) || len(tl.Trash) != 0 {
+		t.Errorf("restauradas %v, lixeira %v", restored, ids(tl.Trash))
+	}
+	if tl.Tasks[2].ParentID != 2 || tl.Tasks[2].IsDeleted() {
+		t.Errorf("subtarefa restaurada: %+v", tl.Tasks[2])
+	}
+}
+
+// Restaurar só a subtarefa, ou uma tarefa cujas dependências foram
+// apagadas, descarta as referências a tarefas que não estão na lista
+func TestRestoreDropsMissingReferences(t *testing.T) {
+	tl := NewTodoList()
+	tl.AddTask("release", "")
+	if _, err := tl.AddSubtask(1, "changelog", ""); err != nil {
+		t.Fatal(err)
+	}
+	tl.AddTask("bloqueadora", "")
+	if err := tl.AddDependency(2, 3); err != nil {
+		t.Fatal(err)
+	}
+	if err := tl.RemoveTaskTree(1); err != nil {
+		t.Fatal(err)
+	}
+	// A bloqueadora é apagada enquanto a dependente está na lixeira
+	if err := tl.RemoveTask(3); err != nil {
+		t.Fatal(err)
+	}
+	if err := tl.PurgeTask(3); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := tl.RestoreTask(2); err != nil {
+		t.Fatal(err)
+	}
+	child, err := tl.GetTask(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if child.ParentID != 0 || len(child.BlockedBy) > 0 {
+		t.Errorf("subtarefa restaurada sem a principal: parent %d, dependências %v", child.ParentID, child.BlockedBy)
+	}
+	if !reflect.DeepEqual(ids(tl.Trash), []int{1}) {
+		t.Errorf("lixeira = %v, esperado [1]", ids(tl.Trash))
+	}
+}
+
+func TestRestoreAndPurgeErrors(t *testing.T) {
+	tl := NewTodoList()
+	tl.AddTask("deploy", "")
+
+	if _, err := tl.RestoreTask(1); !errors.Is(err, ErrNotInTrash) {
+		t.Errorf("restaurar tarefa da lista: erro %v, esperado ErrNotInTrash", err)
+	}
+	if _, err := tl.RestoreTask(9); err == nil || errors.Is(err, ErrNotInTrash) {
+		t.Errorf("restaurar tarefa inexistente: erro %v", err)
+	}
+	if err := tl.PurgeTask(1); !errors.Is(err, ErrNotInTrash) {
+		t.Errorf("apagar da lixeira uma tarefa da lista: erro %v", err)
+	}
+}
+
+func TestPurgeTrash(t *testing.T) {
+	now := time.Date(2026, time.October, 18, 12, 0, 0, 0, time.UTC)
+	tl := NewTodoList()
+	for i, age := range []int{40, 5, 31} {
+		tl.AddTask("velha", "")
+		tl.moveToTrash([]int{i + 1}, now.AddDate(0, 0, -age))
+	}
+	if want := []int{2, 3, 1}; !reflect.DeepEqual(trashed(tl), want) {
+		t.Errorf("lixeira = %v, esperado %v", trashed(tl), want)
+	}
+
+	retention := now.AddDate(0, 0, -DefaultTrashRetentionDays)
+	if purged := tl.PurgeTrash(retention); purged != 2 || !reflect.DeepEqual(ids(tl.Trash), []int{2}) {
+		t.Errorf("apagadas %d, ficaram %v", purged, ids(tl.Trash))
+	}
+	if purged := tl.PurgeTrash(time.Time{}); purged != 1 || tl.Trash != nil {
+		t.Errorf("esvaziar: apagadas %d, ficaram %v", purged, ids(tl.Trash))
+	}
+}
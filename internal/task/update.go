@@ -0,0 +1,128 @@
+package task
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// TaskUpdate descreve uma edição parcial de uma tarefa: apenas os campos
+// não nil são alterados. Due e Recurrence recebem o texto digitado pelo
+// usuário; texto vazio remove o prazo ou a repetição.
+type TaskUpdate struct {
+	Title       *string
+	Description *string
+	Priority    *Priority
+	Due         *string   // formatos aceitos por ParseDue
+	Recurrence  *string   // regras aceitas por ParseRecurrence
+	Tags        *[]string // substitui todas as tags
+	Project     *string   // nome do projeto
+}
+
+// IsEmpty informa se a edição não altera nenhum campo
+func (u TaskUpdate) IsEmpty() bool {
+	return u == TaskUpdate{}
+}
+
+// FieldError indica que o valor informado para um campo é inválido
+type FieldError struct {
+	Field string
+	Err   error
+}
+
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("%s: %v", strings.ToLower(FieldLabel(e.Field)), e.Err)
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// UpdateTask aplica uma edição parcial à tarefa, mantendo ID e data de
+// criação. Todos os campos são validados antes de qualquer alteração: se
+// algum for inválido, a tarefa fica intacta e o erro é um *FieldError.
+// Retorna os campos que de fato mudaram.
+func (tl *TodoList) UpdateTask(id int, update TaskUpdate) ([]FieldChange, error) {
+	current, err := tl.GetTask(id)
+	if err != nil {
+		return nil, err
+	}
+	before := cloneTask(current)
+
+	// validação de todos os campos antes de alterar qualquer um
+	invalid := func(field string, err error) ([]FieldChange, error) {
+		return nil, &FieldError{Field: field, Err: err}
+	}
+
+	title := before.Title
+	var titleTags []string
+	if update.Title != nil {
+		if strings.TrimSpace(*update.Title) == "" {
+			return invalid(FieldTitle, errors.New("não pode ser vazio"))
+		}
+		title, titleTags = splitTitleTags(*update.Title)
+	}
+
+	if update.Priority != nil && !update.Priority.Valid() {
+		return invalid(FieldPriority, fmt.Errorf("valor desconhecido: %d", int(*update.Priority)))
+	}
+
+	due, hasTime := before.DueDate, before.DueHasTime
+	if update.Due != nil {
+		due, hasTime = nil, false
+		if strings.TrimSpace(*update.Due) != "" {
+			parsed, withTime, err := ParseDue(*update.Due, time.Now())
+			if err != nil {
+				return invalid(FieldDue, err)
+			}
+			due, hasTime = &parsed, withTime
+		}
+	}
+
+	recurrence := before.Recurrence
+	if update.Recurrence != nil {
+		recurrence = nil
+		if strings.TrimSpace(*update.Recurrence) != "" {
+			if recurrence, err = ParseRecurrence(*update.Recurrence); err != nil {
+				return invalid(FieldRecurrence, err)
+			}
+		}
+	}
+
+	var tags []string
+	if update.Tags != nil {
+		if tags, err = normalizeTags(*update.Tags); err != nil {
+			return invalid(FieldTags, err)
+		}
+	}
+
+	if update.Project != nil {
+		if err := tl.validateMove(id, *update.Project); err != nil {
+			return invalid(FieldProject, err)
+		}
+	}
+
+	// aplicação: a partir daqui nenhuma alteração falha
+	if update.Tags != nil {
+		current.Tags = nil
+		current.addTags(tags)
+	}
+	current.Title = title
+	current.addTags(titleTags)
+	if update.Description != nil {
+		current.Description = *update.Description
+	}
+	tl.index.add(current)
+	if update.Priority != nil {
+		current.Priority = *update.Priority
+	}
+	tl.SetDueDate(id, due, hasTime)
+	tl.SetRecurrence(id, recurrence)
+	if update.Project != nil {
+		tl.MoveTask(id, *update.Project)
+	}
+
+	after, _ := tl.GetTask(id)
+	return tl.fieldChanges(&before, after), nil
+}
@@ -0,0 +1,118 @@
+package task
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// ptr devolve um ponteiro para o valor, para montar edições parciais
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func TestUpdateTask(t *testing.T) {
+	tl := NewTodoList()
+	if _, err := tl.AddProject("trabalho"); err != nil {
+		t.Fatal(err)
+	}
+	deploy := tl.AddTask("deploy #infra #wip", "")
+	createdAt := deploy.CreatedAt
+
+	changes, err := tl.UpdateTask(1, TaskUpdate{
+		Title:       ptr("Deploy da API #urgente"),
+		Description: ptr("janela das 22h"),
+		Priority:    ptr(PriorityHigh),
+		Due:         ptr("2026-11-03 22:00"),
+		Recurrence:  ptr("weekly:mon"),
+		Tags:        ptr([]string{"Infra", "release"}),
+		Project:     ptr("trabalho"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	deploy, _ = tl.GetTask(1)
+	if deploy.Title != "Deploy da API" || !reflect.DeepEqual(deploy.Tags, []string{"infra", "release", "urgente"}) {
+		t.Errorf("título %q, tags %v", deploy.Title, deploy.Tags)
+	}
+	if deploy.DueDate == nil || !deploy.DueHasTime || deploy.FormatDue() != "03/11/2026 22:00" {
+		t.Errorf("prazo: %s", deploy.FormatDue())
+	}
+	if deploy.ID != 1 || !deploy.CreatedAt.Equal(createdAt) {
+		t.Errorf("ID %d, criada em %v: campos fixos alterados", deploy.ID, deploy.CreatedAt)
+	}
+	var fields []string
+	for _, change := range changes {
+		fields = append(fields, change.Field)
+	}
+	want := []string{FieldTitle, FieldDescription, FieldPriority, FieldDue, FieldTags, FieldProject, FieldRecurrence}
+	if !reflect.DeepEqual(fields, want) {
+		t.Errorf("campos alterados %v, esperado %v", fields, want)
+	}
+
+	// Texto vazio remove prazo e repetição; campos nil ficam como estão
+	changes, err = tl.UpdateTask(1, TaskUpdate{Due: ptr(""), Recurrence: ptr(" ")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if deploy.DueDate != nil || deploy.Recurrence != nil || len(changes) != 2 || deploy.Priority != PriorityHigh {
+		t.Errorf("após remover prazo e repetição: %+v, alterações %+v", deploy, changes)
+	}
+
+	// Uma edição que repete os valores atuais não muda nada
+	if changes, err := tl.UpdateTask(1, TaskUpdate{Title: ptr("Deploy da API")}); err != nil || changes != nil {
+		t.Errorf("edição sem mudanças: %+v, erro %v", changes, err)
+	}
+	if _, err := tl.UpdateTask(9, TaskUpdate{Title: ptr("x")}); err == nil {
+		t.Error("edição de tarefa inexistente aceita")
+	}
+}
+
+// Um campo inválido rejeita a edição inteira, mesmo com os demais válidos
+func TestUpdateTaskAllOrNothing(t *testing.T) {
+	tl := NewTodoList()
+	if _, err := tl.AddProject("arquivado"); err != nil {
+		t.Fatal(err)
+	}
+	if err := tl.SetProjectArchived("arquivado", true); err != nil {
+		t.Fatal(err)
+	}
+	tl.AddTask("release", "")
+	child, err := tl.AddSubtask(1, "changelog #docs", "revisar")
+	if err != nil {
+		t.Fatal(err)
+	}
+	due := time.Date(2026, time.November, 3, 0, 0, 0, 0, time.Local)
+	child.DueDate = &due
+	original, _ := json.Marshal(tl)
+
+	for field, update := range map[string]TaskUpdate{
+		FieldTitle:      {Title: ptr("  "), Description: ptr("nova")},
+		FieldPriority:   {Title: ptr("novo"), Priority: ptr(Priority(99))},
+		FieldDue:        {Title: ptr("novo"), Due: ptr("semana que vem")},
+		FieldRecurrence: {Title: ptr("novo"), Due: ptr(""), Recurrence: ptr("às vezes")},
+		FieldTags:       {Title: ptr("novo"), Tags: ptr([]string{"ok", "a+b"})},
+		FieldProject:    {Title: ptr("novo"), Tags: ptr([]string{}), Project: ptr("arquivado")},
+	} {
+		_, err := tl.UpdateTask(child.ID, update)
+		var fieldErr *FieldError
+		if !errors.As(err, &fieldErr) || fieldErr.Field != field {
+			t.Errorf("%s: erro %v, esperado um *FieldError do campo", field, err)
+		}
+		if current, _ := json.Marshal(tl); string(current) != string(original) {
+			t.Fatalf("%s: lista alterada por uma edição rejeitada:\n%s", field, current)
+		}
+	}
+
+	// Subtarefas acompanham o projeto da principal e não mudam sozinhas
+	if _, err := tl.AddProject("trabalho"); err != nil {
+		t.Fatal(err)
+	}
+	_, err = tl.UpdateTask(child.ID, TaskUpdate{Project: ptr("trabalho")})
+	if want := "projeto: subtarefa [2] acompanha o projeto da tarefa principal [1]"; err == nil || err.Error() != want {
+		t.Errorf("subtarefa em outro projeto: erro %v, esperado %q", err, want)
+	}
+}
@@ -0,0 +1,155 @@
+package task
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// MaxPinnedViews é quantas visões podem ser fixadas no menu principal
+const MaxPinnedViews = 5
+
+// ErrViewNotFound indica que não existe visão com o nome informado
+var ErrViewNotFound = errors.New("visão não encontrada")
+
+// View é uma consulta salva com um nome, como "infra-semana", para ser
+// executada de novo sempre que preciso (veja ParseQuery), com a sua
+// ordenação padrão (veja ParseSortSpec). Visões fixadas aparecem no menu
+// principal com a contagem de tarefas.
+type View struct {
+	Name      string    `json:"name"`
+	Query     string    `json:"query"`
+	Sort      string    `json:"sort,omitempty"`
+	Pinned    bool      `json:"pinned,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Parse interpreta a consulta da visão
+func (v *View) Parse(now time.Time) (*Query, error) {
+	return ParseQuery(v.Query, now)
+}
+
+// SortSpec interpreta a ordenação da visão; nil mantém a ordem de relevância
+func (v *View) SortSpec() (SortSpec, error) {
+	return ParseSortSpec(v.Sort)
+}
+
+// SaveView cria a visão ou, se já existir uma com o nome, troca a sua
+// consulta e ordenação (vazia para a ordem de relevância). Ambas são
+// validadas antes de serem salvas. Retorna a visão e se ela foi criada.
+func (tl *TodoList) SaveView(name, query, sortSpec string) (*View, bool, error) {
+	name, err := validateViewName(name)
+	if err != nil {
+		return nil, false, err
+	}
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, false, fmt.Errorf("a consulta da visão não pode ser vazia")
+	}
+	if _, err := ParseQuery(query, time.Now()); err != nil {
+		return nil, false, err
+	}
+	spec, err := ParseSortSpec(sortSpec)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if view, err := tl.findView(name); err == nil {
+		view.Query = query
+		view.Sort = spec.String()
+		return view, false, nil
+	}
+
+	tl.Views = append(tl.Views, View{Name: name, Query: query, Sort: spec.String(), CreatedAt: time.Now()})
+	return &tl.Views[len(tl.Views)-1], true, nil
+}
+
+// FindView retorna uma visão pelo nome, sem diferenciar maiúsculas
+func (tl *TodoList) FindView(name string) (View, error) {
+	view, err := tl.findView(name)
+	if err != nil {
+		return View{}, err
+	}
+	return *view, nil
+}
+
+// RemoveView apaga uma visão. As tarefas não são afetadas.
+func (tl *TodoList) RemoveView(name string) error {
+	view, err := tl.findView(name)
+	if err != nil {
+		return err
+	}
+
+	kept := tl.Views[:0]
+	for _, v := range tl.Views {
+		if v.Name != view.Name {
+			kept = append(kept, v)
+		}
+	}
+	tl.Views = kept
+	return nil
+}
+
+// SetViewPinned fixa a visão no menu principal ou a desafixa. No máximo
+// MaxPinnedViews visões ficam fixadas.
+func (tl *TodoList) SetViewPinned(name string, pinned bool) error {
+	view, err := tl.findView(name)
+	if err != nil {
+		return err
+	}
+	if pinned && !view.Pinned && len(tl.PinnedViews()) >= MaxPinnedViews {
+		return fmt.Errorf("já existem %d visões fixadas; desafixe uma antes", MaxPinnedViews)
+	}
+
+	view.Pinned = pinned
+	return nil
+}
+
+// ListViews retorna as visões em ordem alfabética
+func (tl *TodoList) ListViews() []View {
+	views := append([]View(nil), tl.Views...)
+	sort.Slice(views, func(i, j int) bool {
+		return strings.ToLower(views[i].Name) < strings.ToLower(views[j].Name)
+	})
+	return views
+}
+
+// PinnedViews retorna as visões fixadas, em ordem alfabética
+func (tl *TodoList) PinnedViews() []View {
+	var pinned []View
+	for _, view := range tl.ListViews() {
+		if view.Pinned {
+			pinned = append(pinned, view)
+		}
+	}
+	return pinned
+}
+
+// findView busca uma visão pelo nome, sem diferenciar maiúsculas
+func (tl *TodoList) findView(name string) (*View, error) {
+	name = strings.TrimSpace(name)
+	for i := range tl.Views {
+		if strings.EqualFold(tl.Views[i].Name, name) {
+			return &tl.Views[i], nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", ErrViewNotFound, name)
+}
+
+// validateViewName exige um nome de uma palavra (letras, números, "-" e
+// "_"), para que a visão possa ser chamada como "todo view <nome>"
+func validateViewName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return name, fmt.Errorf("nome da visão não pode ser vazio")
+	}
+	for _, r := range name {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '-' && r != '_' {
+			return name, fmt.Errorf("nome da visão inválido '%s': use letras, números, '-' ou '_'", name)
+		}
+	}
+	return name, nil
+}
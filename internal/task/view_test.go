@@ -0,0 +1,87 @@
+package task
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestSaveView(t *testing.T) {
+	tl := NewTodoList()
+	view, created, err := tl.SaveView(" infra-semana ", " tag:infra due<=semana ", "due")
+	if err != nil || !created {
+		t.Fatalf("criar: %v, criada %v", err, created)
+	}
+	if view.Name != "infra-semana" || view.Query != "tag:infra due<=semana" || view.Sort != "due:asc" {
+		t.Errorf("visão salva: %+v", view)
+	}
+
+	// O mesmo nome, em outra caixa, altera a visão existente
+	if _, created, err := tl.SaveView("INFRA-SEMANA", "tag:infra", ""); err != nil || created {
+		t.Fatalf("alterar: %v, criada %v", err, created)
+	}
+	if found, err := tl.FindView("Infra-Semana"); err != nil || found.Query != "tag:infra" || found.Sort != "" {
+		t.Errorf("visão alterada: %+v, erro %v", found, err)
+	}
+	if len(tl.Views) != 1 {
+		t.Errorf("visões: %v", tl.Views)
+	}
+
+	for _, tt := range []struct{ name, query, sort, want string }{
+		{"", "tag:infra", "", "nome da visão não pode ser vazio"},
+		{"infra semana", "tag:infra", "", "nome da visão inválido"},
+		{"infra/semana", "tag:infra", "", "nome da visão inválido"},
+		{"infra", " ", "", "a consulta da visão não pode ser vazia"},
+		{"infra", "due<amanhã-ou-depois", "", "prazo"},
+		{"infra", "tag:infra", "tamanho", "critério de ordenação desconhecido"},
+	} {
+		_, _, err := tl.SaveView(tt.name, tt.query, tt.sort)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("SaveView(%q, %q, %q): erro %v, esperado %q", tt.name, tt.query, tt.sort, err, tt.want)
+		}
+	}
+
+	if err := tl.RemoveView("INFRA-semana"); err != nil || len(tl.Views) != 0 {
+		t.Errorf("remover: %v, visões %v", err, tl.Views)
+	}
+	if err := tl.RemoveView("infra-semana"); !errors.Is(err, ErrViewNotFound) {
+		t.Errorf("remover de novo: erro %v, esperado ErrViewNotFound", err)
+	}
+}
+
+func TestMaxPinnedViews(t *testing.T) {
+	tl := NewTodoList()
+	names := []string{"f", "e", "d", "c", "b", "a"}
+	for _, name := range names {
+		if _, _, err := tl.SaveView(name, "tag:"+name, ""); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, name := range names[:MaxPinnedViews] {
+		if err := tl.SetViewPinned(name, true); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := tl.SetViewPinned("a", true); err == nil {
+		t.Errorf("fixada a visão %d", MaxPinnedViews+1)
+	}
+	// Fixar de novo uma visão já fixada não conta como uma nova
+	if err := tl.SetViewPinned("b", true); err != nil {
+		t.Errorf("fixar de novo: %v", err)
+	}
+
+	if err := tl.SetViewPinned("f", false); err != nil {
+		t.Fatal(err)
+	}
+	if err := tl.SetViewPinned("a", true); err != nil {
+		t.Errorf("fixar após desafixar outra: %v", err)
+	}
+	var pinned []string
+	for _, view := range tl.PinnedViews() {
+		pinned = append(pinned, view.Name)
+	}
+	if strings.Join(pinned, ",") != "a,b,c,d,e" {
+		t.Errorf("fixadas: %v, esperado em ordem alfabética", pinned)
+	}
+}
@@ -1,24 +1,62 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"os"
 
 	"github.com/lucianoZgabriel/go-cli-todo/internal/cli"
 	"github.com/lucianoZgabriel/go-cli-todo/internal/storage"
+	"github.com/lucianoZgabriel/go-cli-todo/internal/task"
 )
 
-// Nome padrão do arquivo onde as tarefas serão salvas
-const defaultFileName = "tasks.json"
+// Storage padrão: arquivo JSON onde as tarefas serão salvas
+const defaultStorage = "json:tasks.json"
 
 func main() {
-	// 1. Cria a camada de Storage (implementação JSON)
-	jsonStorage := storage.NewJSONStorage(defaultFileName)
+	storageSpec := flag.String("storage", defaultStorage,
+		"onde salvar as tarefas: json:<arquivo> ou sqlite:<arquivo>")
+	autosaveDelay := flag.Duration("autosave-delay", 0,
+		"no menu, espera este intervalo sem alterações antes de salvar (ex.: 2s); 0 salva após cada ação")
+	undoDepth := flag.Int("undo-depth", task.DefaultHistoryDepth,
+		"quantas operações podem ser desfeitas; 0 desativa o histórico")
+	trashDays := flag.Int("trash-days", task.DefaultTrashRetentionDays,
+		"por quantos dias as tarefas removidas ficam na lixeira; 0 desativa a limpeza automática")
+	flag.Parse()
+
+	if *undoDepth < 0 {
+		fmt.Fprintln(os.Stderr, "❌ -undo-depth não pode ser negativo")
+		os.Exit(cli.ExitUsage)
+	}
+	if *trashDays < 0 {
+		fmt.Fprintln(os.Stderr, "❌ -trash-days não pode ser negativo")
+		os.Exit(cli.ExitUsage)
+	}
+	if *autosaveDelay < 0 {
+		fmt.Fprintln(os.Stderr, "❌ -autosave-delay não pode ser negativo")
+		os.Exit(cli.ExitUsage)
+	}
+
+	// 1. Cria a camada de Storage escolhida
+	store, err := storage.New(*storageSpec)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		os.Exit(cli.ExitUsage)
+	}
 
 	// 2. Cria a CLI injetando o Storage
-	todoApp := cli.NewCLI(jsonStorage)
+	todoApp := cli.NewCLI(store,
+		cli.WithAutosaveDelay(*autosaveDelay),
+		cli.WithUndoDepth(*undoDepth),
+		cli.WithTrashRetention(*trashDays),
+	)
+
+	// 3. Com argumentos, executa o subcomando e encerra com seu código de saída
+	if args := flag.Args(); len(args) > 0 {
+		os.Exit(todoApp.Run(args))
+	}
 
-	// 3. Inicia a aplicação
+	// 4. Sem argumentos, inicia o menu interativo
 	if err := todoApp.Start(); err != nil {
 		fmt.Fprintf(os.Stderr, "❌ Erro ao executar aplicação: %v\n", err)
 		os.Exit(1)